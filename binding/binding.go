@@ -6,9 +6,12 @@ import (
 	"fmt"
 	"reflect"
 	"regexp"
-	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/dave/jennifer/jen"
+	"github.com/google/uuid"
 	"github.com/psyark/notion"
 )
 
@@ -21,6 +24,10 @@ func init() {
 			if s == "_" {
 				return "__"
 			}
+			// ASCII 以外の文字は、異なる文字が同じ名前にならないようコードポイント全体を使用します
+			if r, _ := utf8.DecodeRuneInString(s); r >= utf8.RuneSelf {
+				return fmt.Sprintf("_%x", r)
+			}
 			return fmt.Sprintf("_%d", s[0])
 		})
 	}
@@ -28,6 +35,7 @@ func init() {
 
 // UnmarshalPage は渡されたpageのプロパティ・カバー・アイコンをdstに格納します
 // dstは適切にタグ付けされたstructへのポインタである必要があります
+// notion:",id" や notion:",icon" のような予約されたタグ（pageFields を参照）を持つフィールドには、ページのIDやアイコンなどが格納されます
 func UnmarshalPage(page *notion.Page, dst any) error {
 	t := reflect.TypeOf(dst)
 	v := reflect.ValueOf(dst)
@@ -41,31 +49,23 @@ func UnmarshalPage(page *notion.Page, dst any) error {
 		return fmt.Errorf("dst must be a pointer to a tagged struct")
 	}
 
-	setProperty := func(fv reflect.Value, prop *notion.PropertyValue) (err error) {
-		defer func() {
-			if r := recover(); r != nil {
-				err = fmt.Errorf("%v", r)
-			}
-		}()
-
-		if payload, err := getPayload(prop); err != nil {
-			return err
-		} else {
-			fv.Set(reflect.ValueOf(payload))
-		}
-		return err
-	}
-
 	for i := 0; i < t.NumField(); i++ {
 		sf := t.Field(i)
-		if sf.Tag.Get("notion") != "" {
-			propId := sf.Tag.Get("notion") // TODO カバーやアイコンの考慮
+		propId, meta := parseTag(sf)
+		switch {
+		case meta != "":
+			pf, err := pageField(page, meta, sf.Type)
+			if err != nil {
+				return fmt.Errorf("notion.UnmarshalPage(%v): %w", sf.Name, err)
+			}
+			v.Field(i).Set(pf)
+		case propId != "":
 			prop := page.Properties.Get(propId)
 			if prop == nil {
 				return fmt.Errorf("タグ %q に相当するプロパティがありません", propId)
 			}
 
-			if err := setProperty(v.Field(i), prop); err != nil {
+			if err := unmarshalProperty(v.Field(i), prop); err != nil {
 				return fmt.Errorf("notion.UnmarshalPage(%v): %w", sf.Name, err)
 			}
 		}
@@ -77,6 +77,7 @@ func UnmarshalPage(page *notion.Page, dst any) error {
 // GetUpdatePageParams は渡されたsrcと現在のpageを比較し、
 // プロパティ・カバー・アイコンを更新するためのUpdatePageParams、または更新が不要な場合のnilを返します
 // srcは適切にタグ付けされたstruct（またはそのポインタ）である必要があります
+// 予約されたタグのうち、",icon"・",cover"・",in_trash" 以外のフィールドは無視されます
 func GetUpdatePageParams(src any, page *notion.Page) (*notion.UpdatePagePropertiesParams, error) {
 	t := reflect.TypeOf(src)
 	v := reflect.ValueOf(src)
@@ -90,24 +91,41 @@ func GetUpdatePageParams(src any, page *notion.Page) (*notion.UpdatePageProperti
 		return nil, fmt.Errorf("src must be a pointer to a tagged struct")
 	}
 
+	params := notion.UpdatePagePropertiesParams{}
 	delta := notion.PropertyValueMap{}
 	for i := 0; i < t.NumField(); i++ {
-		if sf := t.Field(i); sf.Tag.Get("notion") != "" {
-			propId := sf.Tag.Get("notion") // TODO カバーやアイコンの考慮
+		sf := t.Field(i)
+		propId, meta := parseTag(sf)
+		switch {
+		case meta != "":
+			pf, err := pageField(page, meta, sf.Type)
+			if err != nil {
+				return nil, err
+			}
+			if !writablePageFields[meta] {
+				continue
+			}
+			json1, _ := json.Marshal(pf.Interface())
+			json2, _ := json.Marshal(v.Field(i).Interface())
+			if !bytes.Equal(json1, json2) {
+				setPageField(params, meta, v.Field(i))
+			}
+		case propId != "":
 			prop := page.Properties.Get(propId)
 			if prop == nil {
 				return nil, fmt.Errorf("タグ %q に相当するプロパティがありません", propId)
 			}
 
-			payload, err := getPayload(prop)
-			if err != nil {
-				return nil, err
+			// 現在の値をフィールドと同じ型に変換して比較します
+			current := reflect.New(sf.Type).Elem()
+			if err := unmarshalProperty(current, prop); err != nil {
+				return nil, fmt.Errorf("notion.GetUpdatePageParams(%v): %w", sf.Name, err)
 			}
-			json1, _ := json.Marshal(payload)
+			json1, _ := json.Marshal(current.Interface())
 			json2, _ := json.Marshal(v.Field(i).Interface())
 			if !bytes.Equal(json1, json2) {
 				pv := notion.PropertyValue{Type: prop.Type}
-				if err := setPayload(&pv, v.Field(i).Interface()); err != nil {
+				if err := marshalProperty(&pv, v.Field(i)); err != nil {
 					return nil, err
 				}
 				delta[propId] = pv
@@ -115,32 +133,177 @@ func GetUpdatePageParams(src any, page *notion.Page) (*notion.UpdatePageProperti
 		}
 	}
 
-	var params *notion.UpdatePagePropertiesParams
 	if len(delta) != 0 {
-		params = &notion.UpdatePagePropertiesParams{}
 		params.Properties(delta)
 	}
-
-	return params, nil
+	if len(params) == 0 {
+		return nil, nil
+	}
+	return &params, nil
 }
 
 // GetCreatePageParams は渡されたsrcからdbの定義に従って
 // プロパティ・カバー・アイコンが設定されたCreatePageParamsを返します
 // srcは適切にタグ付けされたstruct（またはそのポインタ）である必要があります
-func GetCreatePageParams(src any, db *notion.Database) *notion.CreatePageParams {
-	return nil
+// 未設定を表すフィールド（unset を参照）と、APIから設定できない種類（formula、rollup、created_time など）のプロパティは無視されます
+// 数値の 0 や bool の false は未設定ではないため、プロパティ値として設定されます
+// 予約されたタグのうち、",icon"・",cover" 以外のフィールドも無視されます
+func GetCreatePageParams(src any, db *notion.Database) (*notion.CreatePageParams, error) {
+	v := reflect.Indirect(reflect.ValueOf(src))
+	if v.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("src must be a tagged struct or a pointer to it")
+	}
+
+	t := v.Type()
+	props := notion.PropertyValueMap{}
+	params := notion.CreatePageParams{}
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		propId, meta := parseTag(sf)
+		switch {
+		case meta != "":
+			if _, err := pageField(&notion.Page{}, meta, sf.Type); err != nil {
+				return nil, err
+			}
+			// 作成時に指定できるのはアイコンとカバーのみです。親は db です
+			if !v.Field(i).IsZero() {
+				switch meta {
+				case "icon":
+					params.Icon(v.Field(i).Interface().(notion.FileOrEmoji))
+				case "cover":
+					params.Cover(*v.Field(i).Interface().(*notion.File))
+				}
+			}
+		case propId != "":
+			prop := findProperty(db, propId)
+			if prop == nil {
+				return nil, fmt.Errorf("タグ %q に相当するプロパティがありません", propId)
+			}
+			if isReadOnly(prop.Type) {
+				continue
+			}
+			// 未設定の値は送信しませんが、フィールドの型の誤りは値によらず報告します
+			if err := checkMarshalable(prop.Type, sf.Type); err != nil {
+				return nil, fmt.Errorf("binding.GetCreatePageParams(%v): %s プロパティ %q: %w", sf.Name, prop.Type, prop.Name, err)
+			}
+			if unset(v.Field(i)) {
+				continue
+			}
+
+			pv := notion.PropertyValue{Type: prop.Type}
+			if err := marshalProperty(&pv, v.Field(i)); err != nil {
+				return nil, fmt.Errorf("binding.GetCreatePageParams(%v): %s プロパティ %q: %w", sf.Name, prop.Type, prop.Name, err)
+			}
+			props[propId] = pv
+		}
+	}
+
+	params.Parent(notion.Parent{Type: "database_id", DatabaseId: db.Id})
+	params.Properties(props)
+	return &params, nil
 }
 
-func ToTaggedStruct(db *notion.Database) string {
-	names := []string{}
+// setPageField は、writablePageFields のいずれかである meta に対応するフィールドの値 fv を params に設定します
+// fv の型は pageField で確認済みである必要があります
+func setPageField(params notion.UpdatePagePropertiesParams, meta string, fv reflect.Value) {
+	switch meta {
+	case "in_trash":
+		params.InTrash(fv.Bool())
+	case "icon":
+		icon, _ := fv.Interface().(notion.FileOrEmoji)
+		params.Icon(icon)
+	case "cover":
+		if cover := fv.Interface().(*notion.File); cover != nil {
+			params.Cover(*cover)
+		} else {
+			// Cover は nil を受け取れないため、カバーの削除は null を直接設定します
+			params["cover"] = nil
+		}
+	}
+}
+
+// findProperty は db のプロパティのうち、IDが propId であるものを返します
+func findProperty(db *notion.Database, propId string) *notion.Property {
 	for _, prop := range db.Properties {
-		names = append(names, prop.Name)
+		if prop.Id == propId {
+			return &prop
+		}
+	}
+	return nil
+}
+
+// unset は、フィールドの値 v が未設定を表すかどうかを返します
+// nil のポインタ・スライス・マップ・インターフェース、空文字列、ゼロ値の time.Time と uuid.UUID を未設定とみなします
+func unset(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Map, reflect.Interface:
+		return v.IsNil()
+	case reflect.String:
+		return v.Len() == 0
+	}
+	switch v := v.Interface().(type) {
+	case time.Time:
+		return v.IsZero()
+	case uuid.UUID:
+		return v == uuid.Nil
 	}
+	return false
+}
+
+// isReadOnly は、種類が typ のプロパティの値がNotionによって生成され、APIから設定できないかどうかを返します
+func isReadOnly(typ string) bool {
+	switch typ {
+	case "formula", "rollup", "created_time", "created_by", "last_edited_time", "last_edited_by", "unique_id", "button":
+		return true
+	}
+	return false
+}
+
+// pageFields は予約されたタグ値と、それに対応する Page のフィールドの名前です
+// notion:",icon" のように、カンマに続けて指定します
+var pageFields = map[string]string{
+	"id":               "Id",
+	"url":              "Url",
+	"public_url":       "PublicUrl",
+	"created_time":     "CreatedTime",
+	"created_by":       "CreatedBy",
+	"last_edited_time": "LastEditedTime",
+	"last_edited_by":   "LastEditedBy",
+	"in_trash":         "InTrash",
+	"parent":           "Parent",
+	"icon":             "Icon",
+	"cover":            "Cover",
+}
 
-	sort.Strings(names)
+// writablePageFields は、pageFields のうち UpdatePageParams で更新できるものです
+var writablePageFields = map[string]bool{"in_trash": true, "icon": true, "cover": true}
 
+// parseTag はフィールドの notion タグを解析し、プロパティIDまたは予約されたタグ値を返します
+func parseTag(sf reflect.StructField) (propId string, meta string) {
+	tag := sf.Tag.Get("notion")
+	if name, meta, ok := strings.Cut(tag, ","); ok {
+		return name, meta
+	}
+	return tag, ""
+}
+
+// pageField は予約されたタグ値 meta に対応する page のフィールドを返します
+// 未知のタグ値の場合や、フィールドの型が typ に代入できない場合はエラーを返します
+func pageField(page *notion.Page, meta string, typ reflect.Type) (reflect.Value, error) {
+	name, ok := pageFields[meta]
+	if !ok {
+		return reflect.Value{}, fmt.Errorf("予約されたタグ %q はありません", ","+meta)
+	}
+	fv := reflect.ValueOf(page).Elem().FieldByName(name)
+	if fv.Type() != typ {
+		return reflect.Value{}, fmt.Errorf("タグ %q のフィールドは %v である必要があります", ","+meta, fv.Type())
+	}
+	return fv, nil
+}
+
+func ToTaggedStruct(db *notion.Database) string {
 	fields := []jen.Code{}
-	for _, name := range names {
+	for _, name := range sortedPropertyNames(db) {
 		prop := db.Properties[name]
 		// data, _ := json.Marshal(prop)
 		fields = append(fields, jen.Id(safeName(prop.Name)).Op(getTypeForBinding(prop)).Tag(map[string]string{"notion": prop.Id}))
@@ -0,0 +1,177 @@
+package binding_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/psyark/notion"
+	"github.com/psyark/notion/binding"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+type record struct {
+	Title       notion.RichTextArray `notion:"title"`
+	Number      *float64             `notion:"num"`
+	Done        bool                 `notion:"done"`
+	Note        notion.RichTextArray `notion:"note"`
+	CreatedTime notion.ISO8601String `notion:"ct"`
+	Formula     *notion.Formula      `notion:"calc"`
+	Icon        notion.FileOrEmoji   `notion:",icon"`
+	Cover       *notion.File         `notion:",cover"`
+	Id          uuid.UUID            `notion:",id"`
+}
+
+func TestGetCreatePageParams(t *testing.T) {
+	db := &notion.Database{
+		Id: uuid.MustParse("11111111-2222-3333-4444-555555555555"),
+		Properties: map[string]notion.Property{
+			"Name":    {Type: "title", Id: "title", Name: "Name"},
+			"Number":  {Type: "number", Id: "num", Name: "Number"},
+			"Done":    {Type: "checkbox", Id: "done", Name: "Done"},
+			"Note":    {Type: "rich_text", Id: "note", Name: "Note"},
+			"Created": {Type: "created_time", Id: "ct", Name: "Created"},
+			"Calc":    {Type: "formula", Id: "calc", Name: "Calc"},
+		},
+	}
+
+	src := record{
+		Title:       notion.NewRichTextArray("hello"),
+		Number:      lo.ToPtr(1.5),
+		CreatedTime: "2024-01-01T00:00:00.000Z",
+		Formula:     &notion.Formula{},
+		Icon:        &notion.Emoji{Emoji: "📝"},
+		Id:          uuid.New(),
+	}
+	params, err := binding.GetCreatePageParams(&src, db)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	data := lo.Must(json.Marshal(params))
+	body := struct {
+		Icon       map[string]any            `json:"icon"`
+		Cover      map[string]any            `json:"cover"`
+		Parent     map[string]any            `json:"parent"`
+		Properties map[string]map[string]any `json:"properties"`
+	}{}
+	lo.Must0(json.Unmarshal(data, &body))
+
+	assert.Equal(t, "database_id", body.Parent["type"])
+	assert.Equal(t, db.Id.String(), body.Parent["database_id"])
+	// bool の false は未設定ではないため送信され、nil のスライスは送信されません
+	assert.ElementsMatch(t, []string{"title", "num", "done"}, lo.Keys(body.Properties))
+	assert.Equal(t, 1.5, body.Properties["num"]["number"])
+	assert.Equal(t, false, body.Properties["done"]["checkbox"])
+	assert.Equal(t, "📝", body.Icon["emoji"])
+	assert.NotContains(t, string(data), `"cover"`)
+
+	t.Run("zero values", func(t *testing.T) {
+		params, err := binding.GetCreatePageParams(struct {
+			Title  string  `notion:"title"`
+			Number int     `notion:"num"`
+			Ratio  float64 `notion:"ratio"`
+		}{}, &notion.Database{Properties: map[string]notion.Property{
+			"Name":   {Type: "title", Id: "title", Name: "Name"},
+			"Number": {Type: "number", Id: "num", Name: "Number"},
+			"Ratio":  {Type: "number", Id: "ratio", Name: "Ratio"},
+		}})
+		if assert.NoError(t, err) {
+			data := lo.Must(json.Marshal((*params)["properties"]))
+			assert.JSONEq(t, `{"num":{"type":"number","number":0},"ratio":{"type":"number","number":0}}`, string(data))
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := binding.GetCreatePageParams(struct {
+			Missing bool `notion:"missing"`
+		}{true}, db)
+		assert.Error(t, err)
+
+		_, err = binding.GetCreatePageParams(struct {
+			Number string `notion:"num"`
+		}{"1"}, db)
+		assert.ErrorContains(t, err, `"Number"`)
+
+		// 型の誤りは、フィールドが未設定でも報告されます
+		_, err = binding.GetCreatePageParams(struct {
+			Number *string `notion:"num"`
+		}{}, db)
+		assert.ErrorContains(t, err, `"Number"`)
+
+		_, err = binding.GetCreatePageParams(struct {
+			Note []string `notion:"note"`
+		}{}, db)
+		assert.ErrorContains(t, err, `"Note"`)
+
+		_, err = binding.GetCreatePageParams(1, db)
+		assert.Error(t, err)
+
+		_, err = binding.GetCreatePageParams(struct {
+			Icon *notion.Emoji `notion:",icon"`
+		}{}, db)
+		assert.Error(t, err)
+
+		_, err = binding.GetCreatePageParams(struct {
+			Unknown string `notion:",unknown"`
+		}{}, db)
+		assert.Error(t, err)
+	})
+}
+
+func TestPageFields(t *testing.T) {
+	page := &notion.Page{
+		Id:      uuid.MustParse("11111111-2222-3333-4444-555555555555"),
+		Url:     "https://www.notion.so/page",
+		InTrash: true,
+		Icon:    &notion.Emoji{Emoji: "📝"},
+		Parent:  notion.Parent{Type: "database_id", DatabaseId: uuid.New()},
+		Properties: notion.PropertyValueMap{
+			"Number": {Type: "number", Id: "num", Number: lo.ToPtr(1.0)},
+		},
+	}
+
+	type pageRecord struct {
+		Id      uuid.UUID          `notion:",id"`
+		Url     string             `notion:",url"`
+		InTrash bool               `notion:",in_trash"`
+		Parent  notion.Parent      `notion:",parent"`
+		Icon    notion.FileOrEmoji `notion:",icon"`
+		Cover   *notion.File       `notion:",cover"`
+		Number  *float64           `notion:"num"`
+	}
+
+	dst := pageRecord{}
+	if !assert.NoError(t, binding.UnmarshalPage(page, &dst)) {
+		return
+	}
+	assert.Equal(t, page.Id, dst.Id)
+	assert.Equal(t, page.Url, dst.Url)
+	assert.True(t, dst.InTrash)
+	assert.Equal(t, page.Parent, dst.Parent)
+	assert.Equal(t, page.Icon, dst.Icon)
+
+	params, err := binding.GetUpdatePageParams(&dst, page)
+	if assert.NoError(t, err) {
+		assert.Nil(t, params)
+	}
+
+	dst.Id = uuid.New() // 更新できないフィールドは無視されます
+	dst.Icon = &notion.Emoji{Emoji: "✅"}
+	dst.Cover = &notion.File{External: &notion.FileExternal{Url: "https://example.com/c.png"}}
+	params, err = binding.GetUpdatePageParams(&dst, page)
+	if assert.NoError(t, err) && assert.NotNil(t, params) {
+		assert.ElementsMatch(t, []string{"icon", "cover"}, lo.Keys(*params))
+	}
+
+	// ゴミ箱からの復元とカバーの削除
+	page.Cover = dst.Cover
+	dst.InTrash = false
+	dst.Cover = nil
+	params, err = binding.GetUpdatePageParams(&dst, page)
+	if assert.NoError(t, err) && assert.NotNil(t, params) {
+		data := lo.Must(json.Marshal(params))
+		assert.JSONEq(t, `{"in_trash":false,"icon":{"type":"emoji","emoji":"✅"},"cover":null}`, string(data))
+	}
+}
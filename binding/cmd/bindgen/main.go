@@ -0,0 +1,91 @@
+/*
+bindgen は、Notionのデータベースに対応するタグ付けされたstructを生成します
+
+	//go:generate go run github.com/psyark/notion/binding/cmd/bindgen -o tasks_generated.go <database_id> testdata/db.json
+
+引数にはデータベースのID、またはデータベースオブジェクトのJSONファイル（RetrieveDatabase のレスポンスをキャッシュしたもの）を指定します。
+データベースのIDを指定した場合は、環境変数 NOTION_TOKEN（-token で変更できます）のアクセストークンを使用してデータベースを取得します。
+
+-check を指定した場合はファイルを書き込まず、生成結果が -o のファイルと異なる場合に終了コード1で終了します。
+CIでNotionのスキーマとコミットされたコードの乖離を検出するために使用します。
+*/
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/google/uuid"
+	"github.com/psyark/notion"
+	"github.com/psyark/notion/binding"
+)
+
+func main() {
+	output := flag.String("o", "", "出力するファイル")
+	pkg := flag.String("pkg", os.Getenv("GOPACKAGE"), "出力するファイルのパッケージ名（go generate では省略できます）")
+	tokenEnv := flag.String("token", "NOTION_TOKEN", "アクセストークンを格納した環境変数の名前")
+	check := flag.Bool("check", false, "ファイルを書き込まず、生成結果と異なる場合にエラーにします")
+	flag.Parse()
+
+	if *output == "" || *pkg == "" || flag.NArg() == 0 {
+		fmt.Fprintln(os.Stderr, "usage: bindgen -o <file> [-pkg <package>] [-check] <database_id|database.json>...")
+		os.Exit(2)
+	}
+
+	if err := run(*output, *pkg, os.Getenv(*tokenEnv), *check, flag.Args()); err != nil {
+		fmt.Fprintln(os.Stderr, "bindgen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(output string, pkg string, token string, check bool, sources []string) error {
+	ctx := context.Background()
+	client := notion.NewClient(token)
+
+	dbs := []*notion.Database{}
+	for _, source := range sources {
+		db, err := loadDatabase(ctx, client, source)
+		if err != nil {
+			return fmt.Errorf("%s: %w", source, err)
+		}
+		dbs = append(dbs, db)
+	}
+
+	code, err := binding.GenerateFile(pkg, "bindgen", dbs)
+	if err != nil {
+		return err
+	}
+
+	if check {
+		current, err := os.ReadFile(output)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(current, code) {
+			return fmt.Errorf("%s is out of date with the Notion schema; run go generate", output)
+		}
+		return nil
+	}
+	return os.WriteFile(output, code, 0666)
+}
+
+// loadDatabase は、source がデータベースのIDの場合はデータベースを取得し、それ以外の場合はJSONファイルとして読み込みます
+func loadDatabase(ctx context.Context, client *notion.Client, source string) (*notion.Database, error) {
+	if id, err := uuid.Parse(source); err == nil {
+		return client.RetrieveDatabase(ctx, id)
+	}
+
+	data, err := os.ReadFile(source)
+	if err != nil {
+		return nil, err
+	}
+	db := &notion.Database{}
+	if err := json.Unmarshal(data, db); err != nil {
+		return nil, err
+	}
+	return db, nil
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	output := filepath.Join(dir, "tasks_generated.go")
+	source := "testdata/tasks.json"
+
+	if !assert.NoError(t, run(output, "tasks", "", false, []string{source})) {
+		return
+	}
+	generated := string(lo.Must(os.ReadFile(output)))
+	assert.Contains(t, generated, "type Tasks struct {")
+	// select プロパティのフィールドは選択肢の型を持ちます
+	assert.Regexp(t, "\tStatus TasksStatus +`notion:\"st\"`", generated)
+	assert.Contains(t, generated, "TasksStatusNotStarted TasksStatus = \"Not started\"")
+
+	t.Run("up to date", func(t *testing.T) {
+		assert.NoError(t, run(output, "tasks", "", true, []string{source}))
+	})
+
+	t.Run("drifted", func(t *testing.T) {
+		// スキーマでプロパティの名前が変更された場合
+		drifted := filepath.Join(dir, "drifted.json")
+		data := strings.ReplaceAll(string(lo.Must(os.ReadFile(source))), `"Points"`, `"Estimate"`)
+		lo.Must0(os.WriteFile(drifted, []byte(data), 0666))
+
+		err := run(output, "tasks", "", true, []string{drifted})
+		assert.ErrorContains(t, err, "is out of date with the Notion schema")
+	})
+
+	t.Run("missing output", func(t *testing.T) {
+		assert.Error(t, run(filepath.Join(dir, "missing.go"), "tasks", "", true, []string{source}))
+	})
+}
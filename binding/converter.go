@@ -0,0 +1,408 @@
+package binding
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/psyark/notion"
+)
+
+// PropertyValueUnmarshaler は、UnmarshalPage でプロパティ値から自身を設定できる型です
+type PropertyValueUnmarshaler interface {
+	// UnmarshalPropertyValue は pv の Type に応じたペイロードを読み取り、自身に格納します
+	UnmarshalPropertyValue(pv *notion.PropertyValue) error
+}
+
+// PropertyValueMarshaler は、GetUpdatePageParams・GetCreatePageParams でプロパティ値に変換できる型です
+type PropertyValueMarshaler interface {
+	// MarshalPropertyValue は、Type が設定済みの pv に自身の値をペイロードとして格納します
+	MarshalPropertyValue(pv *notion.PropertyValue) error
+}
+
+type converterKey struct {
+	propType string
+	goType   reflect.Type
+}
+
+type converter struct {
+	unmarshal func(pv *notion.PropertyValue) (reflect.Value, error)
+	marshal   func(pv *notion.PropertyValue, value reflect.Value) error
+}
+
+// converters は、プロパティの種類と Go の型の組み合わせごとのコンバーターです
+var converters = map[converterKey]converter{}
+
+/*
+RegisterConverter は、種類が propTypes のプロパティと型 T のフィールドを相互に変換するコンバーターを登録します
+
+unmarshal は UnmarshalPage で、marshal は GetUpdatePageParams・GetCreatePageParams で使用されます。
+marshal には Type が設定済みの notion.PropertyValue が渡されます。
+同じ組み合わせのコンバーターが登録済みの場合は置き換えます。
+このパッケージには以下の組み合わせのコンバーターが登録されています
+
+  - string: title、rich_text（プレーンテキスト）、url、email、phone_number、select、status（オプションの名前）
+  - int、float64: number
+  - time.Time、*time.Time: date（開始日時）、created_time、last_edited_time
+  - []string: multi_select（オプションの名前）、relation（ページのID）
+  - uuid.UUID、[]uuid.UUID: relation（ページのID）
+
+string・[]string のコンバーターは、基底型が string である型とそのスライス（GenerateFile が出力する選択肢の型など）にも使用されます。
+*/
+func RegisterConverter[T any](propTypes []string, unmarshal func(pv *notion.PropertyValue) (T, error), marshal func(pv *notion.PropertyValue, value T) error) {
+	c := converter{
+		unmarshal: func(pv *notion.PropertyValue) (reflect.Value, error) {
+			value, err := unmarshal(pv)
+			return reflect.ValueOf(&value).Elem(), err
+		},
+		marshal: func(pv *notion.PropertyValue, value reflect.Value) error {
+			return marshal(pv, value.Interface().(T))
+		},
+	}
+	for _, propType := range propTypes {
+		converters[converterKey{propType, reflect.TypeFor[T]()}] = c
+	}
+}
+
+// findConverter は、種類が propType のプロパティと型 typ のフィールドのコンバーターを返します
+// typ のコンバーターが登録されておらず、typ の基底型が string（またはその要素の基底型が string のスライス）の場合は、
+// string（または []string）のコンバーターを typ との変換を伴って使用します。これにより、選択肢を表す型付きの定数を使用できます
+func findConverter(propType string, typ reflect.Type) (converter, bool) {
+	if c, ok := converters[converterKey{propType, typ}]; ok {
+		return c, true
+	}
+
+	var base reflect.Type
+	switch {
+	case typ.Kind() == reflect.String:
+		base = reflect.TypeFor[string]()
+	case typ.Kind() == reflect.Slice && typ.Elem().Kind() == reflect.String:
+		base = reflect.TypeFor[[]string]()
+	default:
+		return converter{}, false
+	}
+	c, ok := converters[converterKey{propType, base}]
+	if !ok {
+		return converter{}, false
+	}
+	return converter{
+		unmarshal: func(pv *notion.PropertyValue) (reflect.Value, error) {
+			value, err := c.unmarshal(pv)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			return convertString(value, typ), nil
+		},
+		marshal: func(pv *notion.PropertyValue, value reflect.Value) error {
+			return c.marshal(pv, convertString(value, base))
+		},
+	}, true
+}
+
+// convertString は、基底型が string の値、またはその要素の基底型が string のスライス v を型 typ に変換します
+func convertString(v reflect.Value, typ reflect.Type) reflect.Value {
+	if typ.Kind() != reflect.Slice {
+		return v.Convert(typ)
+	}
+	if v.IsNil() {
+		return reflect.Zero(typ)
+	}
+	s := reflect.MakeSlice(typ, v.Len(), v.Len())
+	for i := 0; i < v.Len(); i++ {
+		s.Index(i).Set(v.Index(i).Convert(typ.Elem()))
+	}
+	return s
+}
+
+// unmarshalProperty はプロパティ値 pv をフィールド fv に格納します
+// fv の型が PropertyValueUnmarshaler を実装する場合はそれを、コンバーターが登録されている場合はそれを使用します
+// いずれでもない場合、fv の型は pv のペイロードの型と一致する必要があります
+func unmarshalProperty(fv reflect.Value, pv *notion.PropertyValue) (err error) {
+	if u, ok := fv.Addr().Interface().(PropertyValueUnmarshaler); ok {
+		return u.UnmarshalPropertyValue(pv)
+	}
+	if c, ok := findConverter(pv.Type, fv.Type()); ok {
+		value, err := c.unmarshal(pv)
+		if err != nil {
+			return err
+		}
+		fv.Set(value)
+		return nil
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+
+	payload, err := getPayload(pv)
+	if err != nil {
+		return err
+	}
+	fv.Set(reflect.ValueOf(payload))
+	return nil
+}
+
+// marshalProperty はフィールド fv の値を、Type が設定済みの pv に格納します
+// 使用する変換の優先順位は unmarshalProperty と同じです
+func marshalProperty(pv *notion.PropertyValue, fv reflect.Value) error {
+	if m, ok := fv.Interface().(PropertyValueMarshaler); ok {
+		return m.MarshalPropertyValue(pv)
+	}
+	if fv.CanAddr() {
+		if m, ok := fv.Addr().Interface().(PropertyValueMarshaler); ok {
+			return m.MarshalPropertyValue(pv)
+		}
+	}
+	if c, ok := findConverter(pv.Type, fv.Type()); ok {
+		return c.marshal(pv, fv)
+	}
+	return setPayload(pv, fv.Interface())
+}
+
+// checkMarshalable は、型 typ のフィールドを marshalProperty で種類が propType のプロパティ値に変換できない場合にエラーを返します
+// フィールドの値によらず判定するため、未設定の値を無視する前に型の誤りを検出できます
+func checkMarshalable(propType string, typ reflect.Type) error {
+	marshaler := reflect.TypeFor[PropertyValueMarshaler]()
+	if typ.Implements(marshaler) || reflect.PointerTo(typ).Implements(marshaler) {
+		return nil
+	}
+	if _, ok := findConverter(propType, typ); ok {
+		return nil
+	}
+	payload, err := getPayload(&notion.PropertyValue{Type: propType})
+	if err != nil {
+		return err
+	}
+	if reflect.TypeOf(payload) != typ {
+		return fmt.Errorf("タイプが一致しません: %v を %v に変換できません", typ, reflect.TypeOf(payload))
+	}
+	return nil
+}
+
+func init() {
+	RegisterConverter([]string{"title", "rich_text"},
+		func(pv *notion.PropertyValue) (string, error) {
+			if pv.Type == "title" {
+				return pv.Title.String(), nil
+			}
+			return pv.RichText.String(), nil
+		},
+		func(pv *notion.PropertyValue, value string) error {
+			rta := notion.RichTextArray{}
+			if value != "" {
+				rta = notion.NewRichTextArray(value)
+			}
+			if pv.Type == "title" {
+				pv.Title = rta
+			} else {
+				pv.RichText = rta
+			}
+			return nil
+		},
+	)
+	RegisterConverter([]string{"url", "email", "phone_number"},
+		func(pv *notion.PropertyValue) (string, error) {
+			payload, err := getPayload(pv)
+			if err != nil {
+				return "", err
+			}
+			if s := payload.(*string); s != nil {
+				return *s, nil
+			}
+			return "", nil
+		},
+		func(pv *notion.PropertyValue, value string) error {
+			var s *string
+			if value != "" {
+				s = &value
+			}
+			return setPayload(pv, s)
+		},
+	)
+	RegisterConverter([]string{"select", "status"},
+		func(pv *notion.PropertyValue) (string, error) {
+			payload, err := getPayload(pv)
+			if err != nil {
+				return "", err
+			}
+			if option := payload.(*notion.Option); option != nil {
+				return option.Name, nil
+			}
+			return "", nil
+		},
+		func(pv *notion.PropertyValue, value string) error {
+			var option *notion.Option
+			if value != "" {
+				option = &notion.Option{Name: value}
+			}
+			return setPayload(pv, option)
+		},
+	)
+
+	RegisterConverter([]string{"number"},
+		func(pv *notion.PropertyValue) (float64, error) {
+			if pv.Number != nil {
+				return *pv.Number, nil
+			}
+			return 0, nil
+		},
+		func(pv *notion.PropertyValue, value float64) error {
+			pv.Number = &value
+			return nil
+		},
+	)
+	RegisterConverter([]string{"number"},
+		func(pv *notion.PropertyValue) (int, error) {
+			if pv.Number != nil {
+				return int(*pv.Number), nil
+			}
+			return 0, nil
+		},
+		func(pv *notion.PropertyValue, value int) error {
+			number := float64(value)
+			pv.Number = &number
+			return nil
+		},
+	)
+
+	RegisterConverter([]string{"date", "created_time", "last_edited_time"}, unmarshalTime, marshalTime)
+	RegisterConverter([]string{"date", "created_time", "last_edited_time"},
+		func(pv *notion.PropertyValue) (time.Time, error) {
+			t, err := unmarshalTime(pv)
+			if err != nil || t == nil {
+				return time.Time{}, err
+			}
+			return *t, nil
+		},
+		func(pv *notion.PropertyValue, value time.Time) error {
+			if value.IsZero() {
+				return marshalTime(pv, nil)
+			}
+			return marshalTime(pv, &value)
+		},
+	)
+
+	RegisterConverter([]string{"multi_select", "relation"},
+		func(pv *notion.PropertyValue) ([]string, error) {
+			values := []string{}
+			for _, option := range pv.MultiSelect {
+				values = append(values, option.Name)
+			}
+			for _, ref := range pv.Relation {
+				values = append(values, ref.Id.String())
+			}
+			return values, nil
+		},
+		func(pv *notion.PropertyValue, values []string) error {
+			if pv.Type == "multi_select" {
+				pv.MultiSelect = []notion.Option{}
+				for _, value := range values {
+					pv.MultiSelect = append(pv.MultiSelect, notion.Option{Name: value})
+				}
+				return nil
+			}
+			pv.Relation = []notion.PageReference{}
+			for _, value := range values {
+				id, err := uuid.Parse(value)
+				if err != nil {
+					return err
+				}
+				pv.Relation = append(pv.Relation, notion.PageReference{Id: id})
+			}
+			return nil
+		},
+	)
+	RegisterConverter([]string{"relation"},
+		func(pv *notion.PropertyValue) ([]uuid.UUID, error) {
+			ids := []uuid.UUID{}
+			for _, ref := range pv.Relation {
+				ids = append(ids, ref.Id)
+			}
+			return ids, nil
+		},
+		func(pv *notion.PropertyValue, ids []uuid.UUID) error {
+			pv.Relation = []notion.PageReference{}
+			for _, id := range ids {
+				pv.Relation = append(pv.Relation, notion.PageReference{Id: id})
+			}
+			return nil
+		},
+	)
+	RegisterConverter([]string{"relation"},
+		func(pv *notion.PropertyValue) (uuid.UUID, error) {
+			switch len(pv.Relation) {
+			case 0:
+				return uuid.Nil, nil
+			case 1:
+				return pv.Relation[0].Id, nil
+			}
+			return uuid.Nil, fmt.Errorf("%d 件のページが関連付けられています", len(pv.Relation))
+		},
+		func(pv *notion.PropertyValue, id uuid.UUID) error {
+			pv.Relation = []notion.PageReference{}
+			if id != uuid.Nil {
+				pv.Relation = append(pv.Relation, notion.PageReference{Id: id})
+			}
+			return nil
+		},
+	)
+}
+
+func unmarshalTime(pv *notion.PropertyValue) (*time.Time, error) {
+	value, timeZone := "", (*string)(nil)
+	switch pv.Type {
+	case "date":
+		if pv.Date == nil {
+			return nil, nil
+		}
+		value, timeZone = pv.Date.Start, pv.Date.TimeZone
+	case "created_time":
+		value = pv.CreatedTime
+	case "last_edited_time":
+		value = pv.LastEditedTime
+	}
+	if value == "" {
+		return nil, nil
+	}
+	t, err := parseTime(value, timeZone)
+	return &t, err
+}
+
+func marshalTime(pv *notion.PropertyValue, value *time.Time) error {
+	if pv.Type != "date" {
+		return fmt.Errorf("%s プロパティは更新できません", pv.Type)
+	}
+	pv.Date = nil
+	if value != nil {
+		pv.Date = &notion.PropertyValueDate{Start: formatTime(*value)}
+	}
+	return nil
+}
+
+// parseTime は日付または日時を解釈します
+// オフセットを持たない日時は timeZone（nil の場合はUTC）の時刻として解釈されます
+func parseTime(value string, timeZone *string) (time.Time, error) {
+	loc := time.UTC
+	if timeZone != nil {
+		if l, err := time.LoadLocation(*timeZone); err == nil {
+			loc = l
+		}
+	}
+	for _, layout := range []string{time.RFC3339, time.DateOnly, "2006-01-02T15:04:05", "2006-01-02T15:04"} {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date: %q", value)
+}
+
+// formatTime は t を日時として出力します。ただしUTCの0時ちょうどの場合は日付のみを出力します
+func formatTime(t time.Time) string {
+	if t.Location() == time.UTC && t.Equal(t.Truncate(24*time.Hour)) {
+		return t.Format(time.DateOnly)
+	}
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,147 @@
+package binding_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/psyark/notion"
+	"github.com/psyark/notion/binding"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+// tags はカンマ区切りの multi_select を扱うユーザー定義型です
+type tags string
+
+func (t *tags) UnmarshalPropertyValue(pv *notion.PropertyValue) error {
+	*t = tags(strings.Join(lo.Map(pv.MultiSelect, func(o notion.Option, _ int) string { return o.Name }), ","))
+	return nil
+}
+
+func (t tags) MarshalPropertyValue(pv *notion.PropertyValue) error {
+	if pv.Type != "multi_select" {
+		return fmt.Errorf("unexpected type: %s", pv.Type)
+	}
+	pv.MultiSelect = lo.Map(strings.Split(string(t), ","), func(name string, _ int) notion.Option { return notion.Option{Name: name} })
+	return nil
+}
+
+type nativeRecord struct {
+	Title    string      `notion:"title"`
+	Number   int         `notion:"num"`
+	Select   string      `notion:"sel"`
+	Date     time.Time   `notion:"date"`
+	Created  *time.Time  `notion:"ct"`
+	Multi    []string    `notion:"multi"`
+	Tags     tags        `notion:"multi"`
+	Relation []uuid.UUID `notion:"rel"`
+	Parent   uuid.UUID   `notion:"rel"`
+	URL      string      `notion:"url"`
+}
+
+func TestConverters(t *testing.T) {
+	relId := uuid.New()
+	page := &notion.Page{Properties: notion.PropertyValueMap{
+		"Name":     {Type: "title", Id: "title", Title: notion.NewRichTextArray("hello")},
+		"Number":   {Type: "number", Id: "num", Number: lo.ToPtr(3.0)},
+		"Select":   {Type: "select", Id: "sel", Select: &notion.Option{Name: "A", Id: "a"}},
+		"Date":     {Type: "date", Id: "date", Date: &notion.PropertyValueDate{Start: "2024-03-01"}},
+		"Created":  {Type: "created_time", Id: "ct", CreatedTime: "2024-01-02T03:04:05.000Z"},
+		"Multi":    {Type: "multi_select", Id: "multi", MultiSelect: []notion.Option{{Name: "x"}, {Name: "y"}}},
+		"Relation": {Type: "relation", Id: "rel", Relation: []notion.PageReference{{Id: relId}}},
+		"URL":      {Type: "url", Id: "url"},
+	}}
+
+	dst := nativeRecord{}
+	if !assert.NoError(t, binding.UnmarshalPage(page, &dst)) {
+		return
+	}
+	assert.Equal(t, nativeRecord{
+		Title:    "hello",
+		Number:   3,
+		Select:   "A",
+		Date:     time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		Created:  lo.ToPtr(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)),
+		Multi:    []string{"x", "y"},
+		Tags:     "x,y",
+		Relation: []uuid.UUID{relId},
+		Parent:   relId,
+	}, dst)
+
+	params, err := binding.GetUpdatePageParams(&dst, page)
+	if assert.NoError(t, err) {
+		assert.Nil(t, params, "変更が無い場合は nil を返します")
+	}
+
+	dst.Title = "world"
+	dst.Number = 4
+	dst.Date = time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC)
+	dst.URL = "https://example.com"
+	params, err = binding.GetUpdatePageParams(&dst, page)
+	if assert.NoError(t, err) && assert.NotNil(t, params) {
+		props := (*params)["properties"].(notion.PropertyValueMap)
+		assert.ElementsMatch(t, []string{"title", "num", "date", "url"}, lo.Keys(props))
+		assert.Equal(t, "world", props["title"].Title.String())
+		assert.Equal(t, 4.0, *props["num"].Number)
+		assert.Equal(t, "2024-03-02", props["date"].Date.Start)
+		assert.Equal(t, "https://example.com", *props["url"].Url)
+	}
+
+	t.Run("read only", func(t *testing.T) {
+		dst.Created = lo.ToPtr(time.Now())
+		_, err := binding.GetUpdatePageParams(&dst, page)
+		assert.Error(t, err)
+	})
+}
+
+// optionStatus と optionTag は、GenerateFile が出力する選択肢の型に相当します
+type optionStatus string
+type optionTag string
+
+const (
+	optionStatusTodo optionStatus = "Todo"
+	optionStatusDone optionStatus = "Done"
+	optionTagBug     optionTag    = "bug"
+	optionTagDocs    optionTag    = "docs"
+)
+
+func TestStringKindConverters(t *testing.T) {
+	type optionRecord struct {
+		Status optionStatus `notion:"st"`
+		Tags   []optionTag  `notion:"tg"`
+	}
+
+	page := &notion.Page{Properties: notion.PropertyValueMap{
+		"Status": {Type: "status", Id: "st", Status: &notion.Option{Name: "Todo"}},
+		"Tags":   {Type: "multi_select", Id: "tg", MultiSelect: []notion.Option{{Name: "bug"}}},
+	}}
+
+	dst := optionRecord{}
+	if !assert.NoError(t, binding.UnmarshalPage(page, &dst)) {
+		return
+	}
+	assert.Equal(t, optionRecord{Status: optionStatusTodo, Tags: []optionTag{optionTagBug}}, dst)
+
+	dst.Status = optionStatusDone
+	dst.Tags = append(dst.Tags, optionTagDocs)
+	params, err := binding.GetUpdatePageParams(&dst, page)
+	if assert.NoError(t, err) && assert.NotNil(t, params) {
+		props := (*params)["properties"].(notion.PropertyValueMap)
+		assert.Equal(t, "Done", props["st"].Status.Name)
+		assert.Equal(t, []notion.Option{{Name: "bug"}, {Name: "docs"}}, props["tg"].MultiSelect)
+	}
+
+	db := &notion.Database{Properties: map[string]notion.Property{
+		"Status": {Type: "status", Id: "st", Name: "Status"},
+		"Tags":   {Type: "multi_select", Id: "tg", Name: "Tags"},
+	}}
+	created, err := binding.GetCreatePageParams(optionRecord{Status: optionStatusTodo}, db)
+	if assert.NoError(t, err) {
+		props := (*created)["properties"].(notion.PropertyValueMap)
+		assert.ElementsMatch(t, []string{"st"}, lo.Keys(props))
+		assert.Equal(t, "Todo", props["st"].Status.Name)
+	}
+}
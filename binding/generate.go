@@ -0,0 +1,160 @@
+package binding
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/dave/jennifer/jen"
+	"github.com/psyark/notion"
+)
+
+const notionPath = "github.com/psyark/notion"
+
+/*
+GenerateFile は dbs のそれぞれに対応するタグ付けされたstructを含む、gofmt済みのGoのソースコードを返します
+
+各フィールドにはプロパティの種類と説明がコメントとして付与されます。
+select・multi_select・status プロパティについては、選択肢の名前を値とする型付きの定数も出力され、
+フィールドの型はその型（multi_select の場合はそのスライス）になります。
+generator はヘッダーコメント（"Code generated by <generator>; DO NOT EDIT."）に使用されます。
+異なる名前から同じ識別子が生成される場合はエラーを返します。
+*/
+func GenerateFile(pkg string, generator string, dbs []*notion.Database) ([]byte, error) {
+	file := jen.NewFile(pkg)
+	file.ImportName(notionPath, "notion")
+	file.HeaderComment(fmt.Sprintf("Code generated by %s; DO NOT EDIT.", generator))
+
+	declared := identifiers{} // パッケージレベルの型と定数
+	for _, db := range dbs {
+		name := exportedName(db.Title.String())
+		if err := declared.add(name, db.Title.String()); err != nil {
+			return nil, err
+		}
+		names := sortedPropertyNames(db)
+
+		fields := []jen.Code{}
+		fieldNames := identifiers{}
+		for _, propName := range names {
+			prop := db.Properties[propName]
+			if err := fieldNames.add(exportedName(prop.Name), prop.Name); err != nil {
+				return nil, fmt.Errorf("%s: %w", db.Title.String(), err)
+			}
+			comment := prop.Type
+			if prop.Description != nil && *prop.Description != "" {
+				comment += ": " + strings.ReplaceAll(*prop.Description, "\n", " ")
+			}
+			typ := bindingTypeCode(getTypeForBinding(prop))
+			if propertyOptions(prop) != nil {
+				// 選択肢の型は string のコンバーターで変換されます
+				typ = jen.Id(name + exportedName(prop.Name))
+				if prop.MultiSelect != nil {
+					typ = jen.Index().Add(typ)
+				}
+			}
+			fields = append(fields, jen.Id(exportedName(prop.Name)).Add(typ).Tag(map[string]string{"notion": prop.Id}).Comment(comment))
+		}
+
+		file.Commentf("%s はデータベース「%s」のページです", name, db.Title.String())
+		file.Commentf("%s", db.Url)
+		file.Type().Id(name).Struct(fields...)
+
+		for _, propName := range names {
+			prop := db.Properties[propName]
+			options := propertyOptions(prop)
+			if options == nil {
+				continue
+			}
+
+			typeName := name + exportedName(prop.Name)
+			if err := declared.add(typeName, prop.Name); err != nil {
+				return nil, err
+			}
+			for _, option := range options {
+				if err := declared.add(typeName+exportedName(option.Name), option.Name); err != nil {
+					return nil, err
+				}
+			}
+			file.Line().Commentf("%s はプロパティ「%s」の選択肢です", typeName, prop.Name)
+			file.Type().Id(typeName).String()
+			file.Const().DefsFunc(func(g *jen.Group) {
+				for _, option := range options {
+					g.Id(typeName + exportedName(option.Name)).Id(typeName).Op("=").Lit(option.Name)
+				}
+			})
+		}
+	}
+
+	buf := &bytes.Buffer{}
+	if err := file.Render(buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// identifiers は生成した識別子と、その元になった名前です
+type identifiers map[string]string
+
+// add は source から生成した識別子 id を追加します。同じ識別子が既にある場合はエラーを返します
+func (ids identifiers) add(id string, source string) error {
+	if other, ok := ids[id]; ok {
+		return fmt.Errorf("%q と %q が同じ識別子 %s になります", other, source, id)
+	}
+	ids[id] = source
+	return nil
+}
+
+// sortedPropertyNames は db のプロパティの名前を昇順で返します
+func sortedPropertyNames(db *notion.Database) []string {
+	names := []string{}
+	for _, prop := range db.Properties {
+		names = append(names, prop.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// propertyOptions は select・multi_select・status プロパティの選択肢を返します
+func propertyOptions(prop notion.Property) []notion.OptionDescription {
+	switch {
+	case prop.Select != nil:
+		return prop.Select.Options
+	case prop.MultiSelect != nil:
+		return prop.MultiSelect.Options
+	case prop.Status != nil:
+		return prop.Status.Options
+	}
+	return nil
+}
+
+// bindingTypeCode は getTypeForBinding が返す型の文字列を、notion パッケージのインポートを伴うコードに変換します
+func bindingTypeCode(typ string) *jen.Statement {
+	switch {
+	case strings.HasPrefix(typ, "*"):
+		return jen.Op("*").Add(bindingTypeCode(typ[1:]))
+	case strings.HasPrefix(typ, "[]"):
+		return jen.Index().Add(bindingTypeCode(typ[2:]))
+	case strings.HasPrefix(typ, "notion."):
+		return jen.Qual(notionPath, strings.TrimPrefix(typ, "notion."))
+	case typ == "struct{}":
+		return jen.Struct()
+	}
+	return jen.Id(typ)
+}
+
+// exportedName は s を safeName で識別子に変換し、エクスポートされる名前にします
+func exportedName(s string) string {
+	words := strings.Fields(s)
+	for i, word := range words {
+		r := []rune(word)
+		r[0] = unicode.ToUpper(r[0])
+		words[i] = string(r)
+	}
+	name := safeName(strings.Join(words, ""))
+	if r := []rune(name); len(r) == 0 || !unicode.IsUpper(r[0]) {
+		return "X" + name
+	}
+	return name
+}
@@ -0,0 +1,68 @@
+package binding_test
+
+import (
+	"go/format"
+	"testing"
+
+	"github.com/psyark/notion"
+	"github.com/psyark/notion/binding"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateFile(t *testing.T) {
+	db := &notion.Database{
+		Title: notion.NewRichTextArray("task list"),
+		Url:   "https://www.notion.so/db",
+		Properties: map[string]notion.Property{
+			"Name":   {Type: "title", Id: "title", Name: "Name", Title: &struct{}{}},
+			"Points": {Type: "number", Id: "a%3Db", Name: "Points", Description: lo.ToPtr("見積もり"), Number: &notion.PropertyNumber{}},
+			"Status": {Type: "status", Id: "st", Name: "Status", Status: &notion.PropertyStatus{Options: []notion.OptionDescription{{Name: "Not started"}, {Name: "Done"}}}},
+			"Tags":   {Type: "multi_select", Id: "tg", Name: "Tags", MultiSelect: &notion.PropertyMultiSelect{Options: []notion.OptionDescription{{Name: "bug"}}}},
+		},
+	}
+
+	code, err := binding.GenerateFile("tasks", "bindgen", []*notion.Database{db})
+	if !assert.NoError(t, err) {
+		return
+	}
+	formatted, err := format.Source(code)
+	if assert.NoError(t, err) {
+		assert.Equal(t, string(formatted), string(code))
+	}
+
+	assert.Contains(t, string(code), "// Code generated by bindgen; DO NOT EDIT.\n\npackage tasks\n")
+	assert.Contains(t, string(code), "import \"github.com/psyark/notion\"")
+	assert.Contains(t, string(code), "type TaskList struct {\n\tName   notion.RichTextArray `notion:\"title\"` // title\n\tPoints *float64             `notion:\"a%3Db\"` // number: 見積もり\n\tStatus TaskListStatus       `notion:\"st\"`    // status\n\tTags   []TaskListTags       `notion:\"tg\"`    // multi_select\n")
+	assert.Contains(t, string(code), "type TaskListStatus string")
+	assert.Contains(t, string(code), "TaskListStatusNotStarted TaskListStatus = \"Not started\"")
+	assert.Contains(t, string(code), "TaskListTagsBug TaskListTags = \"bug\"")
+}
+
+func TestGenerateFileIdentifiers(t *testing.T) {
+	db := &notion.Database{
+		Title: notion.NewRichTextArray("タスク"),
+		Properties: map[string]notion.Property{
+			"名前": {Type: "title", Id: "title", Name: "名前", Title: &struct{}{}},
+			"内容": {Type: "rich_text", Id: "body", Name: "内容", RichText: &struct{}{}},
+			"状態": {Type: "select", Id: "st", Name: "状態", Select: &notion.PropertySelect{Options: []notion.OptionDescription{{Name: "未着手"}, {Name: "完了"}}}},
+		},
+	}
+
+	code, err := binding.GenerateFile("tasks", "bindgen", []*notion.Database{db})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Contains(t, string(code), "type X_30bf_30b9_30af struct {\n")
+	assert.Regexp(t, "\tX_5185_5bb9 notion.RichTextArray +`notion:\"body\"` +// rich_text\n", string(code))
+	assert.Regexp(t, "\tX_540d_524d notion.RichTextArray +`notion:\"title\"` // title\n", string(code))
+	assert.Regexp(t, "\tX_72b6_614b X_30bf_30b9_30afX_72b6_614b +`notion:\"st\"` +// select\n", string(code))
+	assert.Regexp(t, "X_30bf_30b9_30afX_72b6_614bX_5b8c_4e86 +X_30bf_30b9_30afX_72b6_614b = \"完了\"", string(code))
+	assert.Contains(t, string(code), "X_30bf_30b9_30afX_72b6_614bX_672a_7740_624b X_30bf_30b9_30afX_72b6_614b = \"未着手\"")
+
+	// 異なる名前から同じ識別子が生成される場合はエラーになります
+	db.Properties["a b"] = notion.Property{Type: "number", Id: "ab1", Name: "a b", Number: &notion.PropertyNumber{}}
+	db.Properties["AB"] = notion.Property{Type: "number", Id: "ab2", Name: "AB", Number: &notion.PropertyNumber{}}
+	_, err = binding.GenerateFile("tasks", "bindgen", []*notion.Database{db})
+	assert.ErrorContains(t, err, `"AB" と "a b" が同じ識別子 AB になります`)
+}
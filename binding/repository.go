@@ -0,0 +1,218 @@
+package binding
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"reflect"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/psyark/notion"
+)
+
+/*
+Repository はデータベースのページを、タグ付けされたstruct T として読み書きします
+
+T は UnmarshalPage と同様にタグ付けされたstructである必要があります。structでない場合、各メソッドはエラーを返します。
+Update と Trash で対象のページを特定するため、T には notion:",id" タグを持つ uuid.UUID のフィールドが必要です。
+データベースのスキーマは最初に必要になった時点で一度だけ取得され、以降はキャッシュされます。
+*/
+type Repository[T any] struct {
+	client     *notion.Client
+	databaseId uuid.UUID
+	options    []notion.CallOption
+
+	mu sync.Mutex
+	db *notion.Database // キャッシュされたデータベース
+}
+
+// NewRepository は databaseId のデータベースに対する Repository を作成します
+// options は全てのリクエストに適用されます
+func NewRepository[T any](client *notion.Client, databaseId uuid.UUID, options ...notion.CallOption) *Repository[T] {
+	return &Repository[T]{client: client, databaseId: databaseId, options: options}
+}
+
+// Database はデータベースを返します。初回の呼び出しでのみデータベースを取得します
+func (r *Repository[T]) Database(ctx context.Context) (*notion.Database, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.db == nil {
+		db, err := r.client.RetrieveDatabase(ctx, r.databaseId, r.options...)
+		if err != nil {
+			return nil, err
+		}
+		r.db = db
+	}
+	return r.db, nil
+}
+
+/*
+List は filter と sorts に一致するページを順に返します。filter が nil の場合は全てのページを返します
+
+filter と sorts の Property には、プロパティの名前・IDの他に、T のフィールドの名前を指定できます。
+フィールドの名前はそのフィールドのタグのプロパティIDに置き換えられます。
+filter はデータベースのスキーマに従って検証され、不正な場合はリクエストを送らずにエラーを返します。
+*/
+func (r *Repository[T]) List(ctx context.Context, filter *notion.Filter, sorts []notion.Sort) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+
+		db, err := r.Database(ctx)
+		if err != nil {
+			yield(zero, err)
+			return
+		}
+
+		fields, err := propertyFields(reflect.TypeFor[T]())
+		if err != nil {
+			yield(zero, err)
+			return
+		}
+		params := notion.QueryDatabaseParams{}
+		if filter != nil {
+			f := resolveFilter(*filter, fields)
+			if err := notion.ValidateFilter(f, db); err != nil {
+				yield(zero, err)
+				return
+			}
+			params.Filter(f)
+		}
+		if sorts != nil {
+			resolved := make([]notion.Sort, len(sorts))
+			for i, sort := range sorts {
+				resolved[i] = sort
+				if propId, ok := fields[sort.Property]; ok {
+					resolved[i].Property = propId
+				}
+			}
+			params.Sorts(resolved)
+		}
+
+		for page, err := range r.client.QueryDatabaseAll(ctx, r.databaseId, params, r.options...) {
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			record := new(T)
+			if err := UnmarshalPage(&page, record); err != nil {
+				yield(zero, err)
+				return
+			}
+			if !yield(*record, nil) {
+				return
+			}
+		}
+	}
+}
+
+// Get は pageId のページを取得します
+func (r *Repository[T]) Get(ctx context.Context, pageId uuid.UUID) (*T, error) {
+	page, err := r.client.RetrievePage(ctx, pageId, r.options...)
+	if err != nil {
+		return nil, err
+	}
+	record := new(T)
+	if err := UnmarshalPage(page, record); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// Create は record からデータベースにページを作成し、作成されたページの内容（IDなど）を record に格納します
+func (r *Repository[T]) Create(ctx context.Context, record *T) error {
+	db, err := r.Database(ctx)
+	if err != nil {
+		return err
+	}
+	params, err := GetCreatePageParams(record, db)
+	if err != nil {
+		return err
+	}
+	page, err := r.client.CreatePage(ctx, *params, r.options...)
+	if err != nil {
+		return err
+	}
+	return UnmarshalPage(page, record)
+}
+
+// Update は record のIDのページを取得し、record との差分を更新します。差分が無い場合はページを更新しません
+// 更新後のページの内容（数式の結果など）は record に格納されます
+func (r *Repository[T]) Update(ctx context.Context, record *T) error {
+	pageId, err := recordId(record)
+	if err != nil {
+		return err
+	}
+	page, err := r.client.RetrievePage(ctx, pageId, r.options...)
+	if err != nil {
+		return err
+	}
+	params, err := GetUpdatePageParams(record, page)
+	if err != nil || params == nil {
+		return err
+	}
+	page, err = r.client.UpdatePageProperties(ctx, pageId, *params, r.options...)
+	if err != nil {
+		return err
+	}
+	return UnmarshalPage(page, record)
+}
+
+// Trash は pageId のページをゴミ箱に移動します
+func (r *Repository[T]) Trash(ctx context.Context, pageId uuid.UUID) error {
+	_, err := r.client.UpdatePageProperties(ctx, pageId, notion.UpdatePagePropertiesParams{}.InTrash(true), r.options...)
+	return err
+}
+
+// recordId は record の notion:",id" タグを持つフィールドの値を返します
+func recordId(record any) (uuid.UUID, error) {
+	v := reflect.Indirect(reflect.ValueOf(record))
+	if v.Kind() != reflect.Struct {
+		return uuid.Nil, fmt.Errorf("%v is not a tagged struct", v.Type())
+	}
+	for i := 0; i < v.NumField(); i++ {
+		if _, meta := parseTag(v.Type().Field(i)); meta == "id" {
+			if id, ok := v.Field(i).Interface().(uuid.UUID); ok && id != uuid.Nil {
+				return id, nil
+			}
+			return uuid.Nil, fmt.Errorf("%v has no page id", v.Type())
+		}
+	}
+	return uuid.Nil, fmt.Errorf("%v has no field tagged with %q", v.Type(), ",id")
+}
+
+// propertyFields は、タグ付けされたstruct t のフィールドの名前とプロパティIDの対応を返します
+// t がstructでない場合はエラーを返します
+func propertyFields(t reflect.Type) (map[string]string, error) {
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%v is not a tagged struct", t)
+	}
+	fields := map[string]string{}
+	for i := 0; i < t.NumField(); i++ {
+		if propId, meta := parseTag(t.Field(i)); propId != "" && meta == "" {
+			fields[t.Field(i).Name] = propId
+		}
+	}
+	return fields, nil
+}
+
+// resolveFilter は、filter とその入れ子のフィルターの Property のうち、fields に含まれるフィールドの名前をプロパティIDに置き換えます
+func resolveFilter(filter notion.Filter, fields map[string]string) notion.Filter {
+	if propId, ok := fields[filter.Property]; ok {
+		filter.Property = propId
+	}
+	resolve := func(filters []notion.Filter) []notion.Filter {
+		if filters == nil {
+			return nil
+		}
+		resolved := make([]notion.Filter, len(filters))
+		for i, f := range filters {
+			resolved[i] = resolveFilter(f, fields)
+		}
+		return resolved
+	}
+	filter.And = resolve(filter.And)
+	filter.Or = resolve(filter.Or)
+	return filter
+}
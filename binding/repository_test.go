@@ -0,0 +1,122 @@
+package binding_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/psyark/notion"
+	"github.com/psyark/notion/binding"
+	"github.com/psyark/notion/notiontest"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+// task は notiontest のデータベースで使用するレコードです
+// notiontest はプロパティIDを無作為に生成するため、IDが固定されている title のみをバインドします
+type task struct {
+	Id      uuid.UUID          `notion:",id"`
+	InTrash bool               `notion:",in_trash"`
+	Icon    notion.FileOrEmoji `notion:",icon"`
+	Title   string             `notion:"title"`
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+func TestRepository(t *testing.T) {
+	ctx := context.Background()
+	server := notiontest.NewServer()
+	defer server.Close()
+
+	client := server.NewClient()
+	params := notion.CreateDatabaseParams{}
+	params.Parent(notion.Parent{PageId: server.AddPage("Root")})
+	params.Title(notion.NewRichTextArray("Tasks"))
+	params.Properties(map[string]notion.PropertySchema{"Name": {Title: &struct{}{}}})
+	db := lo.Must(client.CreateDatabase(ctx, params))
+
+	retrieved := 0
+	repo := binding.NewRepository[task](client, db.Id, notion.WithRoundTripper(roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		if req.Method == http.MethodGet && req.URL.Path == "/v1/databases/"+db.Id.String() {
+			retrieved++
+		}
+		return server.Client().Transport.RoundTrip(req)
+	})))
+
+	for _, name := range []string{"b", "a", "c"} {
+		record := &task{Title: name, Icon: &notion.Emoji{Emoji: "📝"}}
+		if !assert.NoError(t, repo.Create(ctx, record)) {
+			return
+		}
+		assert.NotEqual(t, uuid.Nil, record.Id)
+	}
+
+	list := func(filter *notion.Filter, sorts []notion.Sort) ([]task, error) {
+		records := []task{}
+		for record, err := range repo.List(ctx, filter, sorts) {
+			if err != nil {
+				return nil, err
+			}
+			records = append(records, record)
+		}
+		return records, nil
+	}
+	names := func(records []task) []string {
+		return lo.Map(records, func(r task, _ int) string { return r.Title })
+	}
+
+	// フィルターと並べ替えではフィールドの名前（プロパティの名前 "Name" とは異なる）を使用できます
+	filter := notion.F.Property("Title").Text().DoesNotEqual("c")
+	records, err := list(&filter, []notion.Sort{{Property: "Title", Direction: "ascending"}})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, []string{"a", "b"}, names(records))
+	assert.Equal(t, 1, retrieved, "データベースはキャッシュされます")
+
+	invalid := notion.F.Property("Title").Number().Equals(1)
+	_, err = list(&invalid, nil)
+	assert.Error(t, err)
+
+	record, err := repo.Get(ctx, records[0].Id)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "a", record.Title)
+		assert.Equal(t, "📝", record.Icon.(*notion.Emoji).Emoji)
+	}
+
+	record.Title = "z"
+	if assert.NoError(t, repo.Update(ctx, record)) {
+		assert.Equal(t, "z", lo.Must(repo.Get(ctx, record.Id)).Title)
+	}
+	assert.Error(t, repo.Update(ctx, &task{Title: "no id"}))
+
+	assert.NoError(t, repo.Trash(ctx, records[1].Id))
+	// プロパティの名前も引き続き使用できます
+	records, err = list(nil, []notion.Sort{{Property: "Name", Direction: "descending"}})
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"z", "c"}, names(records))
+	}
+}
+
+func TestRepositoryNonStruct(t *testing.T) {
+	ctx := context.Background()
+	server := notiontest.NewServer()
+	defer server.Close()
+
+	client := server.NewClient()
+	params := notion.CreateDatabaseParams{}
+	params.Parent(notion.Parent{PageId: server.AddPage("Root")})
+	params.Title(notion.NewRichTextArray("Tasks"))
+	params.Properties(map[string]notion.PropertySchema{"Name": {Title: &struct{}{}}})
+	db := lo.Must(client.CreateDatabase(ctx, params))
+
+	// T がstructでない場合、パニックせずにエラーを返します
+	for _, err := range binding.NewRepository[*task](client, db.Id).List(ctx, nil, nil) {
+		assert.Error(t, err)
+	}
+	assert.Error(t, binding.NewRepository[*task](client, db.Id).Update(ctx, lo.ToPtr(&task{Id: uuid.New()})))
+	assert.Error(t, binding.NewRepository[string](client, db.Id).Update(ctx, lo.ToPtr("")))
+}
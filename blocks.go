@@ -0,0 +1,175 @@
+package notion
+
+import "fmt"
+
+// NewParagraph は text を内容とする段落ブロックを作成します
+func NewParagraph(text string, options ...rtOption) Block {
+	return Block{Paragraph: &BlockParagraph{RichText: NewRichTextArray(text, options...)}}
+}
+
+// NewHeading は text を内容とする見出しブロックを作成します。level は1〜3で、それ以外の場合はパニックします
+func NewHeading(level int, text string, options ...rtOption) Block {
+	heading := &BlockHeading{RichText: NewRichTextArray(text, options...)}
+	switch level {
+	case 1:
+		return Block{Heading1: heading}
+	case 2:
+		return Block{Heading2: heading}
+	case 3:
+		return Block{Heading3: heading}
+	}
+	panic(fmt.Sprintf("notion: invalid heading level %d", level))
+}
+
+// NewBulletedListItem は text を内容とする箇条書きリストの項目を作成します
+func NewBulletedListItem(text string, options ...rtOption) Block {
+	return Block{BulletedListItem: &BlockBulletedListItem{RichText: NewRichTextArray(text, options...)}}
+}
+
+// NewNumberedListItem は text を内容とする番号付きリストの項目を作成します
+func NewNumberedListItem(text string, options ...rtOption) Block {
+	return Block{NumberedListItem: &BlockNumberedListItem{RichText: NewRichTextArray(text, options...)}}
+}
+
+// NewToDo は text を内容とするTo doブロックを作成します
+func NewToDo(text string, checked bool, options ...rtOption) Block {
+	return Block{ToDo: &BlockToDo{RichText: NewRichTextArray(text, options...), Checked: &checked}}
+}
+
+// NewToggle は text を見出しとするトグルブロックを作成します
+func NewToggle(text string, options ...rtOption) Block {
+	return Block{Toggle: &BlockToggle{RichText: NewRichTextArray(text, options...)}}
+}
+
+// NewQuote は text を内容とする引用ブロックを作成します
+func NewQuote(text string, options ...rtOption) Block {
+	return Block{Quote: &BlockQuote{RichText: NewRichTextArray(text, options...)}}
+}
+
+// NewCallout は icon と text からなるコールアウトブロックを作成します。icon が nil の場合はアイコンを指定しません
+func NewCallout(icon FileOrEmoji, text string, options ...rtOption) Block {
+	return Block{Callout: &BlockCallout{Icon: icon, RichText: NewRichTextArray(text, options...)}}
+}
+
+// NewCode は language で書かれた source を内容とするコードブロックを作成します
+// language には "go" や "plain text" など、BlockCode.Language に指定できる値を指定します
+func NewCode(language string, source string) Block {
+	return Block{Code: &BlockCode{Language: language, RichText: NewRichTextArray(source)}}
+}
+
+// NewDivider は区切り線のブロックを作成します
+func NewDivider() Block {
+	return Block{Divider: &struct{}{}}
+}
+
+// NewTable は rows の各行を table_row とする表ブロックを作成します
+// 表の列数は最も長い行の長さとなり、それより短い行は空のセルで埋められます
+// 見出し行や見出し列を設定するには、戻り値の Table.HasColumnHeader・Table.HasRowHeader を設定してください
+func NewTable(rows [][]string) Block {
+	width := 0
+	for _, row := range rows {
+		width = max(width, len(row))
+	}
+
+	table := &BlockTable{TableWidth: width}
+	for _, row := range rows {
+		cells := make([]RichTextArray, width)
+		for i := range cells {
+			cells[i] = RichTextArray{}
+			if i < len(row) && row[i] != "" {
+				cells[i] = NewRichTextArray(row[i])
+			}
+		}
+		table.Children = append(table.Children, Block{TableRow: &BlockTableRow{Cells: cells}})
+	}
+	return Block{Table: table}
+}
+
+// ChildrenField はブロックの種類に応じた Children フィールドへのポインタを返します
+// 子ブロックを持てない種類の場合は nil を返します
+func (b *Block) ChildrenField() *[]Block {
+	switch {
+	case b.Paragraph != nil:
+		return &b.Paragraph.Children
+	case b.BulletedListItem != nil:
+		return &b.BulletedListItem.Children
+	case b.ToDo != nil:
+		return &b.ToDo.Children
+	case b.Callout != nil:
+		return &b.Callout.Children
+	case b.Heading1 != nil:
+		return &b.Heading1.Children
+	case b.Heading2 != nil:
+		return &b.Heading2.Children
+	case b.Heading3 != nil:
+		return &b.Heading3.Children
+	case b.SyncedBlock != nil:
+		return &b.SyncedBlock.Children
+	case b.NumberedListItem != nil:
+		return &b.NumberedListItem.Children
+	case b.Quote != nil:
+		return &b.Quote.Children
+	case b.Toggle != nil:
+		return &b.Toggle.Children
+	case b.Table != nil:
+		return &b.Table.Children
+	case b.Template != nil:
+		return &b.Template.Children
+	case b.ColumnList != nil:
+		return &b.ColumnList.Children
+	case b.Column != nil:
+		return &b.Column.Children
+	}
+	return nil
+}
+
+/*
+BlockBuilder はページの内容となるブロックのツリーを組み立てます
+
+	children := notion.NewBlockBuilder().
+		Add(notion.NewHeading(1, "Title")).
+		Nest(notion.NewToggle("Details"), func(b *notion.BlockBuilder) {
+			b.Add(notion.NewParagraph("hidden"))
+		}).
+		Blocks()
+	_, err := client.AppendBlockChildren(ctx, pageId, notion.AppendBlockChildrenParams{}.Children(children))
+*/
+type BlockBuilder struct {
+	blocks []Block
+}
+
+// NewBlockBuilder は空の BlockBuilder を作成します
+func NewBlockBuilder() *BlockBuilder {
+	return &BlockBuilder{}
+}
+
+// Add は blocks を末尾に追加します
+func (b *BlockBuilder) Add(blocks ...Block) *BlockBuilder {
+	b.blocks = append(b.blocks, blocks...)
+	return b
+}
+
+// Nest は、build で組み立てたブロックを子ブロックとする block を末尾に追加します
+// 見出しは子ブロックを持てるよう、トグル見出しになります。block が子ブロックを持てない種類の場合はパニックします
+func (b *BlockBuilder) Nest(block Block, build func(b *BlockBuilder)) *BlockBuilder {
+	children := block.ChildrenField()
+	if children == nil {
+		panic("notion: the block cannot have children")
+	}
+	for _, heading := range []*BlockHeading{block.Heading1, block.Heading2, block.Heading3} {
+		if heading != nil {
+			toggleable := true
+			heading.IsToggleable = &toggleable
+		}
+	}
+
+	nested := NewBlockBuilder()
+	build(nested)
+	*children = append(*children, nested.blocks...)
+	return b.Add(block)
+}
+
+// Blocks は組み立てたブロックを返します
+func (b *BlockBuilder) Blocks() []Block {
+	return b.blocks
+}
@@ -0,0 +1,61 @@
+package notion
+
+import (
+	"testing"
+
+	"github.com/psyark/notion/json"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewBlocks(t *testing.T) {
+	assert.Equal(t, "Title", NewHeading(2, "Title").Heading2.RichText.String())
+	assert.Panics(t, func() { NewHeading(4, "x") })
+	assert.True(t, *NewToDo("task", true).ToDo.Checked)
+	assert.Equal(t, &Emoji{Emoji: "💡"}, NewCallout(&Emoji{Emoji: "💡"}, "tip").Callout.Icon)
+
+	table := NewTable([][]string{{"a", "b"}, {"c"}}).Table
+	assert.Equal(t, 2, table.TableWidth)
+	if assert.Len(t, table.Children, 2) {
+		assert.Equal(t, []RichTextArray{NewRichTextArray("c"), {}}, table.Children[1].TableRow.Cells)
+	}
+
+	data, err := json.Marshal(NewCode("go", "package main"))
+	if assert.NoError(t, err) {
+		typ, _ := getField(data, "type")
+		assert.JSONEq(t, `"code"`, string(typ))
+	}
+}
+
+func TestBlockBuilder(t *testing.T) {
+	blocks := NewBlockBuilder().
+		Add(NewHeading(1, "Title"), NewParagraph("intro")).
+		Nest(NewToggle("Details"), func(b *BlockBuilder) {
+			b.Add(NewParagraph("hidden"))
+			b.Nest(NewBulletedListItem("item"), func(b *BlockBuilder) {
+				b.Add(NewToDo("nested", false))
+			})
+		}).
+		Add(NewDivider()).
+		Blocks()
+
+	if assert.Len(t, blocks, 4) {
+		toggle := blocks[2].Toggle
+		if assert.Len(t, toggle.Children, 2) {
+			assert.Equal(t, "nested", toggle.Children[1].BulletedListItem.Children[0].ToDo.RichText.String())
+		}
+	}
+	assert.Nil(t, blocks[0].Heading1.IsToggleable)
+
+	// 子ブロックを持つ見出しはトグル見出しになります
+	heading := NewBlockBuilder().Nest(NewHeading(2, "Section"), func(b *BlockBuilder) {
+		b.Add(NewParagraph("body"))
+	}).Blocks()[0].Heading2
+	if assert.NotNil(t, heading.IsToggleable) {
+		assert.True(t, *heading.IsToggleable)
+	}
+	assert.Len(t, heading.Children, 1)
+
+	assert.Panics(t, func() {
+		NewBlockBuilder().Nest(NewDivider(), func(b *BlockBuilder) {})
+	})
+}
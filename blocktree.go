@@ -0,0 +1,138 @@
+package notion
+
+import (
+	"context"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+const defaultBlockTreeConcurrency = 4
+
+// BlockTreeOptions は RetrieveBlockTree の動作を指定します
+type BlockTreeOptions struct {
+	MaxDepth           int  // 取得する深さの上限（ルートの子ブロックが1）。0 の場合は制限しません
+	Concurrency        int  // 同時に実行するリクエストの最大数。0 の場合は4です
+	FollowSyncedBlocks bool // 同期ブロックの複製の子ブロックとして、SyncedFrom が参照する元の同期ブロックの子ブロックを取得します
+	FollowChildPages   bool // 子ページの中身（ブロック）を子ブロックとして取得します
+}
+
+// BlockNode はブロックとその子孫ブロックからなるツリーのノードです
+type BlockNode struct {
+	Block    Block
+	Children []BlockNode
+}
+
+/*
+RetrieveBlockTree は blockId のページ（またはブロック）の子孫ブロックを全て取得し、ツリーとして返します
+
+各ブロックの子ブロックはページネーションを辿って全て取得されます。
+兄弟ブロックの子孫は並行して取得され、同時に実行されるリクエストの数は opts.Concurrency に制限されます。
+子ページと子データベースの中身は、opts.FollowChildPages を指定しない限り取得されません。
+いずれかのリクエストが失敗した場合は、残りのリクエストを中止してそのエラーを返します。
+*/
+func (c *Client) RetrieveBlockTree(ctx context.Context, blockId uuid.UUID, opts BlockTreeOptions, options ...CallOption) ([]BlockNode, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultBlockTreeConcurrency
+	}
+
+	f := &blockTreeFetcher{
+		client:  c,
+		opts:    opts,
+		options: options,
+		sem:     make(chan struct{}, concurrency),
+		cancel:  cancel,
+	}
+	nodes := f.fetch(ctx, blockId, 1)
+	if f.err != nil {
+		return nil, f.err
+	}
+	return nodes, nil
+}
+
+type blockTreeFetcher struct {
+	client  *Client
+	opts    BlockTreeOptions
+	options []CallOption
+	sem     chan struct{} // 実行中のリクエストの数を制限するセマフォ
+	cancel  context.CancelFunc
+	errOnce sync.Once
+	err     error // 最初に発生したエラー
+}
+
+// fetch は id の子ブロックを取得し、depth の深さのノードとして返します
+func (f *blockTreeFetcher) fetch(ctx context.Context, id uuid.UUID, depth int) []BlockNode {
+	blocks, err := f.retrieveChildren(ctx, id)
+	if err != nil {
+		f.fail(err)
+		return nil
+	}
+
+	nodes := make([]BlockNode, len(blocks))
+	wg := sync.WaitGroup{}
+	for i, block := range blocks {
+		nodes[i].Block = block
+		if childId, ok := f.childrenSource(block, depth); ok {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				nodes[i].Children = f.fetch(ctx, childId, depth+1)
+			}()
+		}
+	}
+	wg.Wait()
+	return nodes
+}
+
+// retrieveChildren は、セマフォを獲得した上で id の子ブロックを全て取得します
+// 子孫ブロックの取得を待つ間にセマフォを保持しないよう、取得が終わった時点で解放します
+func (f *blockTreeFetcher) retrieveChildren(ctx context.Context, id uuid.UUID) ([]Block, error) {
+	select {
+	case f.sem <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	defer func() { <-f.sem }()
+
+	blocks := []Block{}
+	for block, err := range f.client.RetrieveBlockChildrenAll(ctx, id, RetrieveBlockChildrenParams{}, f.options...) {
+		if err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, block)
+	}
+	return blocks, nil
+}
+
+// childrenSource は、depth の深さにある block の子ノードとして子ブロックを取得するブロックのIDを返します
+func (f *blockTreeFetcher) childrenSource(block Block, depth int) (uuid.UUID, bool) {
+	if f.opts.MaxDepth > 0 && depth >= f.opts.MaxDepth {
+		return uuid.Nil, false
+	}
+	switch {
+	case block.SyncedBlock != nil && block.SyncedBlock.SyncedFrom != nil:
+		if f.opts.FollowSyncedBlocks {
+			return block.SyncedBlock.SyncedFrom.BlockId, true
+		}
+	case block.ChildPage != nil:
+		if f.opts.FollowChildPages {
+			return block.Id, true
+		}
+		return uuid.Nil, false
+	case block.ChildDatabase != nil:
+		return uuid.Nil, false
+	}
+	return block.Id, block.HasChildren
+}
+
+// fail は最初のエラーを記録し、実行中のリクエストを中止します
+func (f *blockTreeFetcher) fail(err error) {
+	f.errOnce.Do(func() {
+		f.err = err
+		f.cancel()
+	})
+}
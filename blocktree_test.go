@@ -0,0 +1,111 @@
+package notion
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// stubBlockTree は、children で表されたツリーの子ブロックを返す CallOption を返します
+// 各ブロックは "<id>:<type>" の形式で表し、子ブロックは2件ずつページネーションされます
+func stubBlockTree(children map[int][]string, maxInFlight *int32) CallOption {
+	var inFlight int32
+	mu := sync.Mutex{}
+	return WithRoundTripper(roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		mu.Lock()
+		*maxInFlight = max(*maxInFlight, n)
+		mu.Unlock()
+		time.Sleep(5 * time.Millisecond)
+
+		var parent int
+		fmt.Sscanf(strings.Split(req.URL.Path, "/")[3], "00000000-0000-0000-0000-%012d", &parent)
+		items := children[parent]
+		start := 0
+		fmt.Sscan(req.URL.Query().Get("start_cursor"), &start)
+		end := min(start+2, len(items))
+
+		results := []string{}
+		for _, item := range items[start:end] {
+			var id int
+			var typ string
+			fmt.Sscanf(strings.Replace(item, ":", " ", 1), "%d %s", &id, &typ)
+			content := `{"rich_text":[],"color":"default"}`
+			switch typ {
+			case "child_page":
+				content = `{"title":"Child"}`
+			case "synced_block":
+				content = `{"synced_from":{"type":"block_id","block_id":"00000000-0000-0000-0000-000000000002"}}`
+			}
+			results = append(results, fmt.Sprintf(`{"object":"block","id":"00000000-0000-0000-0000-%012d","type":%q,"has_children":%v,%q:%s}`, id, typ, len(children[id]) != 0, typ, content))
+		}
+		next, hasMore := "null", end < len(items)
+		if hasMore {
+			next = fmt.Sprintf(`"%d"`, end)
+		}
+		body := fmt.Sprintf(`{"object":"list","type":"block","block":{},"has_more":%v,"next_cursor":%s,"results":[%s]}`, hasMore, next, strings.Join(results, ","))
+		return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: io.NopCloser(strings.NewReader(body))}, nil
+	}))
+}
+
+func TestRetrieveBlockTree(t *testing.T) {
+	ctx := context.Background()
+	root := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	tree := map[int][]string{
+		1:  {"2:paragraph", "3:paragraph", "4:paragraph", "5:child_page", "6:synced_block"},
+		2:  {"20:paragraph", "21:paragraph"},
+		3:  {"30:paragraph"},
+		4:  {"40:paragraph"},
+		5:  {"50:paragraph"},
+		20: {"200:paragraph"},
+	}
+	// ids はノードのIDをツリーの構造を保って列挙します
+	var ids func(nodes []BlockNode) string
+	ids = func(nodes []BlockNode) string {
+		s := []string{}
+		for _, node := range nodes {
+			id := strings.TrimLeft(strings.TrimPrefix(node.Block.Id.String(), "00000000-0000-0000-0000-"), "0")
+			if len(node.Children) != 0 {
+				id += "(" + ids(node.Children) + ")"
+			}
+			s = append(s, id)
+		}
+		return strings.Join(s, " ")
+	}
+
+	t.Run("default", func(t *testing.T) {
+		var maxInFlight int32
+		nodes, err := (&Client{}).RetrieveBlockTree(ctx, root, BlockTreeOptions{Concurrency: 2}, stubBlockTree(tree, &maxInFlight))
+		if assert.NoError(t, err) {
+			assert.Equal(t, "2(20(200) 21) 3(30) 4(40) 5 6", ids(nodes))
+			assert.LessOrEqual(t, maxInFlight, int32(2))
+		}
+	})
+
+	t.Run("follow", func(t *testing.T) {
+		var maxInFlight int32
+		opts := BlockTreeOptions{MaxDepth: 2, FollowChildPages: true, FollowSyncedBlocks: true}
+		nodes, err := (&Client{}).RetrieveBlockTree(ctx, root, opts, stubBlockTree(tree, &maxInFlight))
+		if assert.NoError(t, err) {
+			assert.Equal(t, "2(20 21) 3(30) 4(40) 5(50) 6(20 21)", ids(nodes))
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		failing := WithRoundTripper(roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			return nil, io.ErrUnexpectedEOF
+		}))
+		_, err := (&Client{}).RetrieveBlockTree(ctx, root, BlockTreeOptions{}, failing)
+		assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	})
+}
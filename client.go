@@ -5,24 +5,77 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"maps"
 	"net/http"
+	"net/url"
+	"strings"
 
 	"github.com/psyark/notion/json"
 )
 
 const APIVersion = "2022-06-28"
 
-func NewClient(accessToken string) *Client {
-	return &Client{accessToken: accessToken}
+// DefaultBaseURL は Notion API のベースURLです
+const DefaultBaseURL = "https://api.notion.com"
+
+func NewClient(accessToken string, options ...ClientOption) *Client {
+	c := &Client{accessToken: accessToken, baseURL: DefaultBaseURL}
+	for _, o := range options {
+		o(c)
+	}
+	return c
 }
 
 type Client struct {
 	accessToken string
+	baseURL     string
+	httpClient  *http.Client
+	callOptions []CallOption
+	limiter     *RateLimiter
+}
+
+type ClientOption func(*Client)
+
+// WithBaseURL は、リクエストの送信先を baseURL（例: "http://localhost:8080"）に変更します
+// ローカルのスタブサーバーやプロキシを使用する場合に指定します
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) {
+		c.baseURL = strings.TrimSuffix(baseURL, "/")
+	}
+}
+
+// WithHTTPClient は、リクエストの送信に httpClient を使用します
+// タイムアウトやプロキシ、Cookie Jarを設定する場合に指定します
+// 呼び出しごとに WithRoundTripper が指定された場合はそちらが優先されます
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithCallOptions は、この Client を通じた全てのリクエストに options を適用します
+// 呼び出しごとに指定された CallOption はこれらの後に適用されます
+func WithCallOptions(options ...CallOption) ClientOption {
+	return func(c *Client) {
+		c.callOptions = append(c.callOptions, options...)
+	}
+}
+
+// WithRateLimiter は、この Client を通じた全てのリクエストを limiter で制限します
+// 同じ limiter を複数の Client に渡すと、それらの間で制限を共有します
+func WithRateLimiter(limiter *RateLimiter) ClientOption {
+	return func(c *Client) {
+		c.limiter = limiter
+	}
 }
 
 type callOptions struct {
 	roundTripper http.RoundTripper
 	validator    func(wantBytes []byte, got any) error
+	retryPolicy  *RetryPolicy
+	retrySafe    bool
+	middlewares  []Middleware
+	query        map[string]any
 }
 
 type CallOption func(*callOptions)
@@ -39,67 +92,206 @@ func WithValidator(validator func(data []byte, unmarshaler any) error) CallOptio
 	}
 }
 
+// WithQuery は、GET・DELETE のリクエストのクエリ文字列に query を追加します
+// params を引数に取らないメソッド（例: RetrievePagePropertyItem）にパラメータを指定する場合に使用します
+func WithQuery(query map[string]any) CallOption {
+	return func(co *callOptions) {
+		if co.query == nil {
+			co.query = map[string]any{}
+		}
+		maps.Copy(co.query, query)
+	}
+}
+
 func accessValue[T any](v T) T {
 	return v
 }
 
-func call[U any, R any](ctx context.Context, accessToken string, method string, path string, params map[string]any, accessor func(unmarshaler U) R, options ...CallOption) (R, error) {
-	var unmarshaler U
+func call[U any, R any](ctx context.Context, c *Client, endpoint string, method string, path string, params map[string]any, accessor func(unmarshaler U) R, options ...CallOption) (R, error) {
 	var zero R
 
-	co := &callOptions{roundTripper: http.DefaultTransport}
+	co := &callOptions{}
+	for _, o := range c.callOptions {
+		o(co)
+	}
 	for _, o := range options {
 		o(co)
 	}
 
-	payload, err := json.Marshal(params)
-	if err != nil {
-		return zero, err
+	req := &Request{
+		Endpoint:    endpoint,
+		Method:      method,
+		Path:        path,
+		AccessToken: c.accessToken,
+		Header:      http.Header{},
+	}
+	switch method {
+	case http.MethodGet, http.MethodDelete:
+		// ボディを持たないメソッドではパラメータをクエリ文字列として送信します
+		query := maps.Clone(co.query)
+		if query == nil {
+			query = map[string]any{}
+		}
+		maps.Copy(query, params)
+		if len(query) != 0 {
+			req.Query = encodeQuery(query)
+		}
+	default:
+		payload, err := json.Marshal(params)
+		if err != nil {
+			return zero, err
+		}
+		req.Body = payload
+	}
+
+	var handler Handler = func(ctx context.Context, req *Request) (any, error) {
+		resBody, err := c.do(ctx, co, req)
+		if err != nil {
+			return nil, err
+		}
+
+		var unmarshaler U
+		if err := json.Unmarshal(resBody, &unmarshaler); err != nil {
+			return nil, err
+		}
+
+		if co.validator != nil {
+			if err := co.validator(resBody, accessor(unmarshaler)); err != nil {
+				return nil, err
+			}
+		}
+
+		return accessor(unmarshaler), nil
+	}
+	for i := len(co.middlewares) - 1; i >= 0; i-- {
+		handler = co.middlewares[i](handler)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, method, "https://api.notion.com"+path, bytes.NewBuffer(payload))
+	result, err := handler(ctx, req)
 	if err != nil {
 		return zero, err
 	}
+	if result == nil {
+		return zero, nil
+	}
+	if result, ok := result.(R); ok {
+		return result, nil
+	}
+	return zero, fmt.Errorf("%v: unexpected result type %T", endpoint, result)
+}
 
-	req.Header.Add("Authorization", "Bearer "+accessToken)
-	req.Header.Add("Notion-Version", APIVersion)
+// do は、必要に応じてレート制限による待機と再試行を行いながら req を送信し、成功したレスポンスのボディを返します
+func (c *Client) do(ctx context.Context, co *callOptions, req *Request) ([]byte, error) {
+	for retries := 0; ; retries++ {
+		if c.limiter != nil {
+			if err := c.limiter.Wait(ctx); err != nil {
+				return nil, err
+			}
+		}
 
-	switch method {
-	case http.MethodPost, http.MethodPatch:
-		req.Header.Add("Content-Type", "application/json")
+		res, err := c.send(ctx, co, req)
+		var resBody []byte
+		if err == nil {
+			resBody, err = io.ReadAll(res.Body)
+			res.Body.Close()
+		}
+		if err != nil {
+			err := &TransportError{Method: req.Method, Path: req.Path, Err: err}
+			if delay, ok := co.retryDelay(req.Method, nil, err, retries); ok {
+				if err := sleep(ctx, delay); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			return nil, err
+		}
+
+		if res.StatusCode == http.StatusOK {
+			return resBody, nil
+		}
+
+		if delay, ok := co.retryDelay(req.Method, res, nil, retries); ok {
+			if err := sleep(ctx, delay); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		return nil, newError(req.Method, req.Path, res, resBody)
+	}
+}
+
+// newError は200以外のレスポンスから Error を作成します
+func newError(method string, path string, res *http.Response, resBody []byte) Error {
+	errBody := Error{}
+	if err := json.Unmarshal(resBody, &errBody); err != nil || errBody.Object != "error" {
+		errBody = Error{Message: string(resBody)}
+	}
+	if errBody.Status == 0 {
+		errBody.Status = res.StatusCode
 	}
+	errBody.Method = method
+	errBody.Path = path
+	return errBody
+}
 
-	res, err := co.roundTripper.RoundTrip(req)
-	if err != nil {
-		return zero, err
+// send はリクエストを1回送信します
+// 再試行のたびにボディを読み直せるよう、req からHTTPリクエストを毎回作成します
+func (c *Client) send(ctx context.Context, co *callOptions, req *Request) (*http.Response, error) {
+	var body io.Reader
+	if req.Body != nil {
+		body = bytes.NewReader(req.Body)
+	}
+
+	baseURL := c.baseURL
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
 	}
 
-	defer res.Body.Close()
+	target := baseURL + req.Path
+	if req.Query != "" {
+		target += "?" + req.Query
+	}
 
-	resBody, err := io.ReadAll(res.Body)
+	httpReq, err := http.NewRequestWithContext(ctx, req.Method, target, body)
 	if err != nil {
-		return zero, err
+		return nil, err
 	}
 
-	if res.StatusCode != http.StatusOK {
-		errBody := Error{}
-		if err := json.Unmarshal(resBody, &errBody); err != nil {
-			return zero, fmt.Errorf("bad status: %v, %v", res.Status, string(resBody))
-		} else {
-			return zero, errBody
-		}
+	for key, values := range req.Header {
+		httpReq.Header[key] = values
 	}
+	httpReq.Header.Set("Authorization", "Bearer "+req.AccessToken)
+	httpReq.Header.Set("Notion-Version", APIVersion)
 
-	if err := json.Unmarshal(resBody, &unmarshaler); err != nil {
-		return zero, err
+	switch req.Method {
+	case http.MethodPost, http.MethodPatch:
+		httpReq.Header.Set("Content-Type", "application/json")
 	}
 
-	if co.validator != nil {
-		if err := co.validator(resBody, accessor(unmarshaler)); err != nil {
-			return zero, err
-		}
+	switch {
+	case co.roundTripper != nil:
+		return co.roundTripper.RoundTrip(httpReq)
+	case c.httpClient != nil:
+		return c.httpClient.Do(httpReq)
+	default:
+		return http.DefaultTransport.RoundTrip(httpReq)
 	}
+}
 
-	return accessor(unmarshaler), nil
+// encodeQuery は params をクエリ文字列にエンコードします
+// スライスの値は同名のパラメータの繰り返しになります
+func encodeQuery(params map[string]any) string {
+	query := url.Values{}
+	for key, value := range params {
+		switch value := value.(type) {
+		case []string:
+			for _, v := range value {
+				query.Add(key, v)
+			}
+		default:
+			query.Add(key, fmt.Sprint(value))
+		}
+	}
+	return query.Encode()
 }
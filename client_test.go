@@ -0,0 +1,146 @@
+package notion
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/psyark/notion/json"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// stubResponse は、リクエストを記録し、常に status と body を返す CallOption を返します
+func stubResponse(requests *[]*http.Request, status int, body string) CallOption {
+	return WithRoundTripper(roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		*requests = append(*requests, req)
+		return &http.Response{
+			StatusCode: status,
+			Status:     http.StatusText(status),
+			Header:     http.Header{},
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    req,
+		}, nil
+	}))
+}
+
+func TestCallQueryParams(t *testing.T) {
+	ctx := context.Background()
+	blockId := uuid.MustParse("c02fc1d3-db8b-45c5-a222-27595b15aea7")
+
+	requests := []*http.Request{}
+	stub := stubResponse(&requests, http.StatusOK, `{"object":"list","results":[],"has_more":false,"next_cursor":null,"type":"block","block":{}}`)
+
+	params := RetrieveBlockChildrenParams{}
+	params.StartCursor("abc").PageSize(10)
+	if _, err := (&Client{}).RetrieveBlockChildren(ctx, blockId, params, stub); err != nil {
+		t.Fatal(err)
+	}
+
+	req := requests[0]
+	assert.Equal(t, "/v1/blocks/c02fc1d3-db8b-45c5-a222-27595b15aea7/children", req.URL.Path)
+	assert.Equal(t, "abc", req.URL.Query().Get("start_cursor"))
+	assert.Equal(t, "10", req.URL.Query().Get("page_size"))
+	assert.Nil(t, req.Body)
+}
+
+func TestWithQuery(t *testing.T) {
+	ctx := context.Background()
+	pageId := uuid.MustParse("c02fc1d3-db8b-45c5-a222-27595b15aea7")
+
+	requests := []*http.Request{}
+	stub := stubResponse(&requests, http.StatusOK, `{"object":"list","results":[],"has_more":false,"next_cursor":null,"type":"property_item","property_item":{"type":"title","title":{},"next_url":null,"id":"title"}}`)
+
+	// params を引数に取らないメソッドでも WithQuery でパラメータを指定できます
+	params := RetrievePagePropertyItemParams{}
+	params.StartCursor("abc").PageSize(10)
+	if _, err := (&Client{}).RetrievePagePropertyItem(ctx, pageId, "title", WithQuery(params), stub); err != nil {
+		t.Fatal(err)
+	}
+
+	req := requests[0]
+	assert.Equal(t, "/v1/pages/c02fc1d3-db8b-45c5-a222-27595b15aea7/properties/title", req.URL.Path)
+	assert.Equal(t, "abc", req.URL.Query().Get("start_cursor"))
+	assert.Equal(t, "10", req.URL.Query().Get("page_size"))
+}
+
+func TestClientOptions(t *testing.T) {
+	ctx := context.Background()
+
+	paths := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		w.Write([]byte(`{"object":"user","id":"00000000-0000-0000-0000-000000000001"}`))
+	}))
+	defer server.Close()
+
+	validated := 0
+	client := NewClient("token",
+		WithBaseURL(server.URL+"/"),
+		WithHTTPClient(server.Client()),
+		WithCallOptions(WithValidator(func(data []byte, unmarshaler any) error {
+			validated++
+			return nil
+		})),
+	)
+
+	if _, err := client.RetrieveBotUser(ctx); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []string{"/v1/users/me"}, paths)
+	assert.Equal(t, 1, validated)
+
+	// 呼び出しごとの CallOption が優先されます
+	requests := []*http.Request{}
+	if _, err := client.RetrieveBotUser(ctx, stubResponse(&requests, http.StatusOK, `{"object":"user","id":"00000000-0000-0000-0000-000000000001"}`)); err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, paths, 1)
+	assert.Len(t, requests, 1)
+	assert.Equal(t, 2, validated)
+}
+
+func TestUpdateBlockParams(t *testing.T) {
+	ctx := context.Background()
+	blockId := uuid.MustParse("c02fc1d3-db8b-45c5-a222-27595b15aea7")
+	rta, _ := json.Marshal(NewRichTextArray("text"))
+
+	// 指定していないフィールドは送信されず、既存の値を上書きしません
+	tests := map[string]struct {
+		params UpdateBlockParams
+		want   string
+	}{
+		"language only": {UpdateBlockParams{}.Code(BlockCode{Language: "go"}), `{"code":{"language":"go"}}`},
+		"text only":     {UpdateBlockParams{}.Heading1(BlockHeading{RichText: NewRichTextArray("text")}), `{"heading_1":{"rich_text":` + string(rta) + `}}`},
+		"untoggle":      {UpdateBlockParams{}.Heading2(BlockHeading{IsToggleable: lo.ToPtr(false)}), `{"heading_2":{"is_toggleable":false}}`},
+		"color only":    {UpdateBlockParams{}.Paragraph(BlockParagraph{Color: "red"}), `{"paragraph":{"color":"red"}}`},
+		"quote text":    {UpdateBlockParams{}.Quote(BlockQuote{RichText: NewRichTextArray("text")}), `{"quote":{"rich_text":` + string(rta) + `}}`},
+		"toggle color":  {UpdateBlockParams{}.Toggle(BlockToggle{Color: "blue"}), `{"toggle":{"color":"blue"}}`},
+		"row header":    {UpdateBlockParams{}.Table(BlockTable{HasRowHeader: lo.ToPtr(true)}), `{"table":{"has_row_header":true}}`},
+		"table row":     {UpdateBlockParams{}.TableRow(BlockTableRow{Cells: []RichTextArray{NewRichTextArray("text")}}), `{"table_row":{"cells":[` + string(rta) + `]}}`},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			requests := []*http.Request{}
+			stub := stubResponse(&requests, http.StatusOK, `{"object":"block","id":"c02fc1d3-db8b-45c5-a222-27595b15aea7","type":"divider","divider":{}}`)
+			if _, err := (&Client{}).UpdateBlock(ctx, blockId, tt.params, stub); err != nil {
+				t.Fatal(err)
+			}
+			body, err := io.ReadAll(requests[0].Body)
+			if assert.NoError(t, err) {
+				assert.JSONEq(t, tt.want, string(body))
+			}
+		})
+	}
+}
@@ -21,7 +21,9 @@ func TestRetrieveBlockChildren(t *testing.T) {
 	t.Parallel()
 
 	Fetch("https://developers.notion.com/reference/get-block-children").Generate(GenericStructRef{Name: "Pagination", GenericTypeArg: "Block"}, ParamAnnotations{
-		"block_id": UUID,
+		"block_id":     UUID,
+		"start_cursor": jen.String(),
+		"page_size":    jen.Int(),
 	})
 }
 
@@ -32,3 +34,45 @@ func TestDeleteBlock(t *testing.T) {
 		"block_id": UUID,
 	})
 }
+
+func TestRetrieveBlock(t *testing.T) {
+	t.Parallel()
+
+	Fetch("https://developers.notion.com/reference/retrieve-a-block").Generate(StructRef("Block"), ParamAnnotations{
+		"block_id": UUID,
+	})
+}
+
+func TestUpdateBlock(t *testing.T) {
+	t.Parallel()
+
+	Fetch("https://developers.notion.com/reference/update-a-block").Generate(StructRef("Block"), ParamAnnotations{
+		"block_id": UUID,
+		"archived": jen.Bool(),
+		"in_trash": jen.Bool(),
+	}, TypeSpecificParams(ParamAnnotations{
+		"audio":              jen.Id("File"),
+		"bookmark":           jen.Id("BlockBookmark"),
+		"bulleted_list_item": jen.Id("BlockBulletedListItem"),
+		"callout":            jen.Id("BlockCallout"),
+		"code":               jen.Id("BlockCode"),
+		"embed":              jen.Id("BlockEmbed"),
+		"equation":           jen.Id("BlockEquation"),
+		"file":               jen.Id("File"),
+		"heading_1":          jen.Id("BlockHeading"),
+		"heading_2":          jen.Id("BlockHeading"),
+		"heading_3":          jen.Id("BlockHeading"),
+		"image":              jen.Id("File"),
+		"link_to_page":       jen.Id("BlockLinkToPage"),
+		"numbered_list_item": jen.Id("BlockNumberedListItem"),
+		"paragraph":          jen.Id("BlockParagraph"),
+		"pdf":                jen.Id("BlockPdf"),
+		"quote":              jen.Id("BlockQuote"),
+		"table":              jen.Id("BlockTable"),
+		"table_of_contents":  jen.Id("BlockTableOfContents"),
+		"table_row":          jen.Id("BlockTableRow"),
+		"to_do":              jen.Id("BlockToDo"),
+		"toggle":             jen.Id("BlockToggle"),
+		"video":              jen.Id("File"),
+	}))
+}
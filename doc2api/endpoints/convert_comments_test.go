@@ -0,0 +1,28 @@
+package endpoints_test
+
+import (
+	"testing"
+
+	"github.com/dave/jennifer/jen"
+	. "github.com/psyark/notion/doc2api/endpoints"
+)
+
+func TestCreateComment(t *testing.T) {
+	t.Parallel()
+
+	Fetch("https://developers.notion.com/reference/create-a-comment").Generate(StructRef("Comment"), ParamAnnotations{
+		"parent":        jen.Id("Parent"),
+		"discussion_id": UUID,
+		"rich_text":     jen.Id("RichTextArray"),
+	})
+}
+
+func TestListComments(t *testing.T) {
+	t.Parallel()
+
+	Fetch("https://developers.notion.com/reference/retrieve-a-comment").Generate(GenericStructRef{Name: "Pagination", GenericTypeArg: "Comment"}, ParamAnnotations{
+		"block_id":     UUID,
+		"start_cursor": jen.String(),
+		"page_size":    jen.Int(),
+	}, MethodName("ListComments"))
+}
@@ -31,9 +31,11 @@ func TestRetrievePagePropertyItem(t *testing.T) {
 	t.Parallel()
 
 	Fetch("https://developers.notion.com/reference/retrieve-a-page-property").Generate(Interface("PropertyItemOrPropertyItemPagination"), ParamAnnotations{
-		"page_id":     UUID,
-		"property_id": jen.String(),
-	})
+		"page_id":      UUID,
+		"property_id":  jen.String(),
+		"page_size":    jen.Int(),
+		"start_cursor": jen.String(),
+	}, ParamsByQueryOption())
 }
 
 func TestUpdatePageProperties(t *testing.T) {
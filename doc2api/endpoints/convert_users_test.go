@@ -0,0 +1,31 @@
+package endpoints_test
+
+import (
+	"testing"
+
+	"github.com/dave/jennifer/jen"
+	. "github.com/psyark/notion/doc2api/endpoints"
+)
+
+func TestListUsers(t *testing.T) {
+	t.Parallel()
+
+	Fetch("https://developers.notion.com/reference/get-users").Generate(GenericStructRef{Name: "Pagination", GenericTypeArg: "User"}, ParamAnnotations{
+		"start_cursor": jen.String(),
+		"page_size":    jen.Int(),
+	}, MethodName("ListUsers"))
+}
+
+func TestRetrieveUser(t *testing.T) {
+	t.Parallel()
+
+	Fetch("https://developers.notion.com/reference/get-user").Generate(StructRef("User"), ParamAnnotations{
+		"user_id": UUID,
+	})
+}
+
+func TestRetrieveBotUser(t *testing.T) {
+	t.Parallel()
+
+	Fetch("https://developers.notion.com/reference/get-self").Generate(StructRef("User"), ParamAnnotations{}, MethodName("RetrieveBotUser"))
+}
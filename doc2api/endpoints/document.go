@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/PuerkitoBio/goquery"
@@ -15,18 +16,55 @@ import (
 )
 
 type endpointDocument struct {
-	url      string
-	ssrProps ssrProps
+	url        string
+	ssrProps   ssrProps
+	name       string
+	typeParams ParamAnnotations
+	paramsOpt  bool
 }
 
 // ParamAnnotations はパラメータの型アノテーションです
 type ParamAnnotations map[string]jen.Code
 
+type generateOption func(r *endpointDocument)
+
+// MethodName は、ドキュメントのタイトルに代えて name をメソッド名（およびファイル名）に使います
+func MethodName(name string) generateOption {
+	return func(r *endpointDocument) {
+		r.name = name
+	}
+}
+
+// TypeSpecificParams は、ドキュメントで "{type}" と表記されるパラメータを
+// タイプ毎のパラメータ（例えば "paragraph", "to_do"）に展開します
+func TypeSpecificParams(annots ParamAnnotations) generateOption {
+	return func(r *endpointDocument) {
+		r.typeParams = annots
+	}
+}
+
+// ParamsByQueryOption は、params をメソッドの引数に加えず、WithQuery で指定させます
+// params を引数に持たずに公開されたメソッドのシグネチャを維持するために使います
+func ParamsByQueryOption() generateOption {
+	return func(r *endpointDocument) {
+		r.paramsOpt = true
+	}
+}
+
 // Generate は、このドキュメントからコードを出力します
-func (r endpointDocument) Generate(returnType ReturnType, paramAnnots ParamAnnotations) {
+func (r endpointDocument) Generate(returnType ReturnType, paramAnnots ParamAnnotations, options ...generateOption) {
+	for _, o := range options {
+		o(&r)
+	}
+
 	file := jen.NewFile("notion")
 
-	hasBodyParams := lo.SomeBy(r.ssrProps.Doc.API.Params, func(p ssrPropsParam) bool { return p.In == "body" })
+	paramsIn := r.paramsIn()
+	hasParams := lo.SomeBy(r.ssrProps.Doc.API.Params, func(p ssrPropsParam) bool { return p.In == paramsIn })
+	if r.paramsOpt && paramsIn != "query" {
+		panic(fmt.Errorf("%s: WithQuery で指定できるのはクエリ文字列のパラメータのみです", r.methodName()))
+	}
+	paramsArg := hasParams && !r.paramsOpt
 
 	// ヘッダーコメント
 	file.HeaderComment("Code generated by notion.doc2api; DO NOT EDIT.")
@@ -45,7 +83,7 @@ func (r endpointDocument) Generate(returnType ReturnType, paramAnnots ParamAnnot
 				g.Id(param.Name).Add(annot)
 			}
 		}
-		if hasBodyParams {
+		if paramsArg {
 			g.Id("params").Id(r.paramsName())
 		}
 
@@ -53,10 +91,11 @@ func (r endpointDocument) Generate(returnType ReturnType, paramAnnots ParamAnnot
 	}).Params(returnType.Type(), jen.Error()).BlockFunc(func(g *jen.Group) {
 		g.Return().Id("call").Call(
 			jen.Line().Id("ctx"),
-			jen.Line().Id("c").Dot("accessToken"),
+			jen.Line().Id("c"),
+			jen.Line().Lit(r.methodName()),
 			jen.Line().Add(jen.Qual("net/http", fmt.Sprintf("Method%s", strcase.UpperCamelCase(r.ssrProps.Doc.API.Method)))),
 			jen.Line().Add(r.pathCode()),
-			jen.Line().Add(lo.Ternary(hasBodyParams, jen.Id("params"), jen.Nil())),
+			jen.Line().Add(lo.Ternary(paramsArg, jen.Id("params"), jen.Nil())),
 			jen.Line().Add(returnType.Accessor()),
 			jen.Line().Id("options").Op("..."),
 			jen.Line(),
@@ -64,22 +103,28 @@ func (r endpointDocument) Generate(returnType ReturnType, paramAnnots ParamAnnot
 	})
 
 	// パラメータの出力
-	if hasBodyParams {
+	if hasParams {
 		file.Type().Id(r.paramsName()).Map(jen.String()).Any()
 
 		for _, param := range r.ssrProps.Doc.API.Params {
-			if param.In == "body" {
+			if param.In == paramsIn {
+				if param.Name == "{type}" {
+					if len(r.typeParams) == 0 {
+						panic(fmt.Errorf("パラメータ %q のアノテーションが存在しません", param.Name))
+					}
+					names := lo.Keys(r.typeParams)
+					slices.Sort(names) // 出力を安定させるため
+					for _, name := range names {
+						r.paramSetter(file, name, param.Desc, r.typeParams[name])
+					}
+					continue
+				}
+
 				annot, ok := paramAnnots[param.Name]
 				if !ok {
 					panic(fmt.Errorf("パラメータ %q のアノテーションが存在しません", param.Name))
 				}
-
-				publicName := strcase.UpperCamelCase(param.Name)
-				file.Comment(param.Desc)
-				file.Func().Params(jen.Id("p").Id(r.paramsName())).Id(publicName).Params(jen.Id(param.Name).Add(annot)).Id(r.paramsName()).Block(
-					jen.Id("p").Index(jen.Lit(param.Name)).Op("=").Id(param.Name),
-					jen.Return().Id("p"),
-				)
+				r.paramSetter(file, param.Name, param.Desc, annot)
 			}
 		}
 	}
@@ -87,7 +132,31 @@ func (r endpointDocument) Generate(returnType ReturnType, paramAnnots ParamAnnot
 	lo.Must0(file.Save(r.fileName()))
 }
 
+// paramSetter は、パラメータを設定するメソッドを出力します
+func (r endpointDocument) paramSetter(file *jen.File, name string, desc string, annot jen.Code) {
+	publicName := strcase.UpperCamelCase(name)
+	file.Comment(desc)
+	file.Func().Params(jen.Id("p").Id(r.paramsName())).Id(publicName).Params(jen.Id(name).Add(annot)).Id(r.paramsName()).Block(
+		jen.Id("p").Index(jen.Lit(name)).Op("=").Id(name),
+		jen.Return().Id("p"),
+	)
+}
+
+// paramsIn は、params として出力するパラメータの位置を返します
+// ボディを持たないメソッドでは、クエリ文字列のパラメータが params になります
+func (r endpointDocument) paramsIn() string {
+	switch r.ssrProps.Doc.API.Method {
+	case "get", "delete":
+		return "query"
+	default:
+		return "body"
+	}
+}
+
 func (r endpointDocument) baseName() string {
+	if r.name != "" {
+		return r.name
+	}
 	return strings.ReplaceAll(r.ssrProps.Doc.Title, " a ", " ")
 }
 func (r endpointDocument) fileName() string {
@@ -18,6 +18,7 @@ type fieldRenderer interface {
 var _ = []fieldRenderer{
 	&VariableField{},
 	&DiscriminatorField{},
+	&UnknownPayloadField{},
 }
 
 // 一般的なフィールド
@@ -70,3 +71,16 @@ func (f *DiscriminatorField) renderField() jen.Code {
 	}
 	return code
 }
+
+// 未知の派生のペイロードを保持するフィールド（UnionStruct.KeepUnknownPayload を参照）
+type UnknownPayloadField struct {
+	comment string
+}
+
+func (f *UnknownPayloadField) renderField() jen.Code {
+	code := jen.Id("UnknownPayload").Qual("github.com/psyark/notion/json", "RawMessage").Tag(map[string]string{"json": "-"})
+	if f.comment != "" {
+		code.Comment(lineBreak.ReplaceAllString(f.comment, " "))
+	}
+	return code
+}
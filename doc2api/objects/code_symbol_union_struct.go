@@ -1,6 +1,8 @@
 package objects
 
 import (
+	"fmt"
+
 	"github.com/dave/jennifer/jen"
 	"github.com/stoewer/go-strcase"
 )
@@ -28,6 +30,23 @@ func (o *UnionStruct) AddPayloadField(discriminatorValue string, comment string,
 	return option(o, field)
 }
 
+// KeepUnknownPayload は、このUnionStructに UnknownPayload フィールドを追加します。
+// discriminator の値がどのペイロードフィールドにも該当しない場合、UnmarshalJSON はそのペイロードを
+// UnknownPayload に保持し、MarshalJSON はそれを書き戻します。
+// これにより、APIに新しく追加された派生なども失われずに往復できます。
+func (o *UnionStruct) KeepUnknownPayload(comment string) {
+	o.AddFields(&UnknownPayloadField{comment: comment})
+}
+
+func (o *UnionStruct) keepsUnknownPayload() bool {
+	for _, f := range o.fields {
+		if _, ok := f.(*UnknownPayloadField); ok {
+			return true
+		}
+	}
+	return false
+}
+
 type addPayloadFieldOption func(union *UnionStruct, field *VariableField) *SimpleObject
 
 func WithType(code jen.Code) addPayloadFieldOption {
@@ -97,8 +116,54 @@ func (o *UnionStruct) code(c *Converter) jen.Code {
 				}
 			}
 		})),
-		jen.Return().Id("omitFields").Call(jen.Id("data"), jen.Id("visibility")),
+		jen.Do(func(s *jen.Statement) {
+			if !o.keepsUnknownPayload() {
+				s.Return().Id("omitFields").Call(jen.Id("data"), jen.Id("visibility"))
+				return
+			}
+			s.List(jen.Id("data"), jen.Err()).Op("=").Id("omitFields").Call(jen.Id("data"), jen.Id("visibility")).Line()
+			s.If(jen.Err().Op("!=").Nil().Op("||").Id("o").Dot("UnknownPayload").Op("==").Nil()).Block(
+				jen.Return().List(jen.Id("data"), jen.Err()),
+			).Line()
+			s.Return().Id("setField").Call(jen.Id("data"), jen.Id("o").Dot(discriminatorProp), jen.Id("o").Dot("UnknownPayload"))
+		}),
 	)
 
+	if o.keepsUnknownPayload() {
+		code.Line().Line().Add(o.unknownPayloadUnmarshalerCode())
+	}
+
+	return code
+}
+
+// unknownPayloadUnmarshalerCode は、未知の派生のペイロードを UnknownPayload に保持する UnmarshalJSON を生成します
+func (o *UnionStruct) unknownPayloadUnmarshalerCode() jen.Code {
+	discriminatorProp := strcase.UpperCamelCase(o.discriminator)
+	knownValues := []jen.Code{}
+	for _, f := range o.fields {
+		if f, ok := f.(*VariableField); ok && f.discriminatorValue != "" {
+			knownValues = append(knownValues, jen.Lit(f.discriminatorValue))
+		}
+	}
+
+	code := jen.Comment("UnmarshalJSON keeps the payload of an unknown " + o.discriminator + " in UnknownPayload").Line()
+	code.Func().Params(jen.Id("o").Op("*").Add(o.typeCode(false))).Id("UnmarshalJSON").Params(jen.Id("data").Index().Byte()).Error().Block(
+		jen.Type().Id("Alias").Add(o.typeCode(false)),
+		jen.If(jen.Err().Op(":=").Qual("github.com/psyark/notion/json", "Unmarshal").Call(jen.Id("data"), jen.Parens(jen.Op("*").Id("Alias")).Call(jen.Id("o")))).Op(";").Err().Op("!=").Nil().Block(
+			jen.Return().Qual("fmt", "Errorf").Call(jen.Lit(fmt.Sprintf("unmarshaling %s: %%w", o.name())), jen.Err()),
+		),
+		jen.Id("o").Dot("UnknownPayload").Op("=").Nil(),
+		jen.Switch(jen.Id("o").Dot(discriminatorProp)).Block(
+			jen.Case(knownValues...),
+			jen.Default().Block(
+				jen.List(jen.Id("payload"), jen.Err()).Op(":=").Id("getField").Call(jen.Id("data"), jen.Id("o").Dot(discriminatorProp)),
+				jen.If(jen.Err().Op("!=").Nil()).Block(
+					jen.Return().Qual("fmt", "Errorf").Call(jen.Lit(fmt.Sprintf("unmarshaling %s: %%w", o.name())), jen.Err()),
+				),
+				jen.Id("o").Dot("UnknownPayload").Op("=").Id("payload"),
+			),
+		),
+		jen.Return().Nil(),
+	)
 	return code
 }
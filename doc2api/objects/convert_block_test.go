@@ -154,6 +154,8 @@ func TestBlock(t *testing.T) {
 	c.ExpectBlock(&Block{
 		Kind: "Blockquote",
 		Text: "📘 The API does not support all block types.Only the block type objects listed in the reference below are supported. Any unsupported block types appear in the structure, but contain a type set to \"unsupported\".",
+	}).Output(func(e *Block, b *CodeBuilder) {
+		block.KeepUnknownPayload("The payload of a block type not known to this library, such as \"unsupported\". It is kept so that the block round-trips through MarshalJSON.")
 	})
 
 	c.ExpectBlock(&Block{
@@ -186,14 +188,14 @@ func TestBlock(t *testing.T) {
 		Type:        "array of rich text objects text",
 		Description: "The caption for the bookmark.",
 	}).Output(func(e *Parameter, b *CodeBuilder) {
-		bookmark.AddFields(b.NewField(e, jen.Id("RichTextArray")))
+		bookmark.AddFields(b.NewField(e, jen.Id("RichTextArray"), OmitEmpty))
 	})
 	c.ExpectParameter(&Parameter{
 		Property:    "url",
 		Type:        "string",
 		Description: "The link for the bookmark.",
 	}).Output(func(e *Parameter, b *CodeBuilder) {
-		bookmark.AddFields(b.NewField(e, jen.String()))
+		bookmark.AddFields(b.NewField(e, jen.String(), OmitEmpty))
 	})
 	c.ExpectBlock(&Block{
 		Kind: "FencedCodeBlock",
@@ -232,7 +234,7 @@ func TestBlock(t *testing.T) {
 		Type:        "array of rich text objects",
 		Description: "The rich text in the bulleted_list_item block.",
 	}).Output(func(e *Parameter, b *CodeBuilder) {
-		bulletedListItem.AddFields(b.NewField(e, jen.Id("RichTextArray")))
+		bulletedListItem.AddFields(b.NewField(e, jen.Id("RichTextArray"), OmitEmpty))
 	})
 	c.ExpectParameter(&Parameter{
 		Property:    "color",
@@ -270,7 +272,7 @@ func TestBlock(t *testing.T) {
 		Type:        "array of rich text objects",
 		Description: "The rich text in the callout block.",
 	}).Output(func(e *Parameter, b *CodeBuilder) {
-		specificObject.AddFields(b.NewField(e, jen.Id("RichTextArray")))
+		specificObject.AddFields(b.NewField(e, jen.Id("RichTextArray"), OmitEmpty))
 	})
 	c.ExpectParameter(&Parameter{
 		Property:    "icon",
@@ -375,14 +377,15 @@ func TestBlock(t *testing.T) {
 		Type:        "array of Rich text object text objects",
 		Description: "The rich text in the code block.",
 	}).Output(func(e *Parameter, b *CodeBuilder) {
-		code.AddFields(b.NewField(e, jen.Id("RichTextArray")))
+		// UpdateBlock で language だけを更新するときに rich_text を null で上書きしないよう、各ブロックのフィールドは omitempty とする
+		code.AddFields(b.NewField(e, jen.Id("RichTextArray"), OmitEmpty))
 	})
 	c.ExpectParameter(&Parameter{
 		Property:    "language",
 		Type:        "- \"abap\" - \"arduino\" - \"bash\" - \"basic\" - \"c\" - \"clojure\" - \"coffeescript\" - \"c++\" - \"c#\" - \"css\" - \"dart\" - \"diff\" - \"docker\" - \"elixir\" - \"elm\" - \"erlang\" - \"flow\" - \"fortran\" - \"f#\" - \"gherkin\" - \"glsl\" - \"go\" - \"graphql\" - \"groovy\" - \"haskell\" - \"html\" - \"java\" - \"javascript\" - \"json\" - \"julia\" - \"kotlin\" - \"latex\" - \"less\" - \"lisp\" - \"livescript\" - \"lua\" - \"makefile\" - \"markdown\" - \"markup\" - \"matlab\" - \"mermaid\" - \"nix\" - \"objective-c\" - \"ocaml\" - \"pascal\" - \"perl\" - \"php\" - \"plain text\" - \"powershell\" - \"prolog\" - \"protobuf\" - \"python\" - \"r\" - \"reason\" - \"ruby\" - \"rust\" - \"sass\" - \"scala\" - \"scheme\" - \"scss\" - \"shell\" - \"sql\" - \"swift\" - \"typescript\" - \"vb.net\" - \"verilog\" - \"vhdl\" - \"visual basic\" - \"webassembly\" - \"xml\" - \"yaml\" - \"java/c/c++/c#\"",
 		Description: "The language of the code contained in the code block.",
 	}).Output(func(e *Parameter, b *CodeBuilder) {
-		code.AddFields(b.NewField(e, jen.String()))
+		code.AddFields(b.NewField(e, jen.String(), OmitEmpty))
 	})
 	c.ExpectBlock(&Block{
 		Kind: "FencedCodeBlock",
@@ -397,7 +400,8 @@ func TestBlock(t *testing.T) {
 		Kind: "Paragraph",
 		Text: "Column lists are parent blocks for columns. They do not contain any information within the column_list property.",
 	}).Output(func(e *Block, b *CodeBuilder) {
-		block.AddPayloadField("column_list", e.Text, WithEmptyStructRef())
+		columnList := block.AddPayloadField("column_list", e.Text, WithPayloadObject(b))
+		columnList.AddFields(b.NewField(&Parameter{Property: "children", Description: UNDOCUMENTED}, jen.Index().Id("Block"), OmitEmpty))
 	})
 	c.ExpectBlock(&Block{
 		Kind: "FencedCodeBlock",
@@ -407,7 +411,8 @@ func TestBlock(t *testing.T) {
 		Kind: "Paragraph",
 		Text: "Columns are parent blocks for any block types listed in this reference except for other columns. They do not contain any information within the column property. They can only be appended to column_lists.",
 	}).Output(func(e *Block, b *CodeBuilder) {
-		block.AddPayloadField("column", e.Text, WithEmptyStructRef())
+		column := block.AddPayloadField("column", e.Text, WithPayloadObject(b))
+		column.AddFields(b.NewField(&Parameter{Property: "children", Description: UNDOCUMENTED}, jen.Index().Id("Block"), OmitEmpty))
 	})
 	c.ExpectBlock(&Block{
 		Kind: "FencedCodeBlock",
@@ -463,7 +468,7 @@ func TestBlock(t *testing.T) {
 		Type:        "string",
 		Description: "The link to the website that the embed block displays.",
 	}).Output(func(e *Parameter, b *CodeBuilder) {
-		embed.AddFields(b.NewField(e, jen.String()))
+		embed.AddFields(b.NewField(e, jen.String(), OmitEmpty))
 	})
 	c.ExpectBlock(&Block{
 		Kind: "FencedCodeBlock",
@@ -495,7 +500,7 @@ func TestBlock(t *testing.T) {
 		Type:        "string",
 		Description: "A KaTeX compatible string.",
 	}).Output(func(e *Parameter, b *CodeBuilder) {
-		equation.AddFields(b.NewField(e, jen.String()))
+		equation.AddFields(b.NewField(e, jen.String(), OmitEmpty))
 	})
 	c.ExpectBlock(&Block{
 		Kind: "FencedCodeBlock",
@@ -559,7 +564,7 @@ func TestBlock(t *testing.T) {
 		Type:        "array of rich text objects",
 		Description: "The rich text of the heading.",
 	}).Output(func(e *Parameter, b *CodeBuilder) {
-		heading.AddFields(b.NewField(e, jen.Id("RichTextArray")))
+		heading.AddFields(b.NewField(e, jen.Id("RichTextArray"), OmitEmpty))
 	})
 	c.ExpectParameter(&Parameter{
 		Property:    "color",
@@ -573,7 +578,8 @@ func TestBlock(t *testing.T) {
 		Type:        "boolean",
 		Description: "Whether or not the heading block is a toggle heading or not. If true, then the heading block toggles and can support children. If false, then the heading block is a static heading block.",
 	}).Output(func(e *Parameter, b *CodeBuilder) {
-		heading.AddFields(b.NewField(e, jen.Bool()))
+		// UpdateBlock で指定しない場合にトグルを解除せず、false を明示した場合は解除できるようポインタとする
+		heading.AddFields(b.NewField(e, jen.Op("*").Bool(), OmitEmpty))
 		heading.AddFields(b.NewField(&Parameter{Property: "children", Description: UNDOCUMENTED}, jen.Index().Id("Block"), OmitEmpty))
 	})
 	c.ExpectBlock(&Block{
@@ -640,6 +646,15 @@ func TestBlock(t *testing.T) {
 		Text: "🚧The link_preview block can only be returned as part of a response. The API does not support creating or appending link_preview blocks.",
 	})
 
+	c.RequestBuilderForUndocumented(func(b *CodeBuilder) {
+		block.AddPayloadField("link_to_page", UNDOCUMENTED, WithType(jen.Op("*").Id("BlockLinkToPage")))
+		linkToPage := b.AddUnionStruct("BlockLinkToPage", "type", UNDOCUMENTED)
+		linkToPage.AddFields(
+			b.NewField(&Parameter{Property: "page_id"}, UUID, DiscriminatorValue("page_id")),
+			b.NewField(&Parameter{Property: "database_id"}, UUID, DiscriminatorValue("database_id")),
+		)
+	})
+
 	c.ExpectBlock(&Block{
 		Kind: "Heading",
 		Text: "Mention",
@@ -679,44 +694,42 @@ func TestBlock(t *testing.T) {
 		// TODO
 	})
 
+	var numberedListItem *SimpleObject
+
 	c.ExpectBlock(&Block{
 		Kind: "Heading",
 		Text: "Numbered list item",
 	}).Output(func(e *Block, b *CodeBuilder) {
-		// TODO
+		numberedListItem = block.AddPayloadField("numbered_list_item", e.Text, WithPayloadObject(b))
 	})
 	c.ExpectBlock(&Block{
 		Kind: "Paragraph",
 		Text: "Numbered list item block objects contain the following information within the numbered_list_item property:",
-	}).Output(func(e *Block, b *CodeBuilder) {
-		// TODO
 	})
 	c.ExpectParameter(&Parameter{
 		Property:    "rich_text",
 		Type:        "array of rich text objects",
 		Description: "The rich text displayed in the numbered_list_item block.",
 	}).Output(func(e *Parameter, b *CodeBuilder) {
-		// TODO
+		numberedListItem.AddFields(b.NewField(e, jen.Id("RichTextArray"), OmitEmpty))
 	})
 	c.ExpectParameter(&Parameter{
 		Property:    "color",
 		Type:        "string (enum)",
 		Description: "The color of the block. Possible values are:  \n  \n- \"blue\"\n- \"blue_background\"\n- \"brown\"\n- \"brown_background\"\n- \"default\"\n- \"gray\"\n- \"gray_background\"\n- \"green\"\n- \"green_background\"\n- \"orange\"\n- \"orange_background\"\n- \"yellow\"\n- \"green\"\n- \"pink\"\n- \"pink_background\"\n- \"purple\"\n- \"purple_background\"\n- \"red\"\n- \"red_background\"\n- \"yellow_background\"",
 	}).Output(func(e *Parameter, b *CodeBuilder) {
-		// TODO
+		numberedListItem.AddFields(b.NewField(e, jen.String(), OmitEmpty))
 	})
 	c.ExpectParameter(&Parameter{
 		Property:    "children",
 		Type:        "array of block objects",
 		Description: "The nested child blocks (if any) of the numbered_list_item block.",
 	}).Output(func(e *Parameter, b *CodeBuilder) {
-		// TODO
+		numberedListItem.AddFields(b.NewField(e, jen.Index().Id("Block"), OmitEmpty))
 	})
 	c.ExpectBlock(&Block{
 		Kind: "FencedCodeBlock",
 		Text: "{\n  //...other keys excluded\n  \"type\": \"numbered_list_item\",\n  \"numbered_list_item\": {\n    \"rich_text\": [\n      {\n        \"type\": \"text\",\n        \"text\": {\n          \"content\": \"Finish reading the docs\",\n          \"link\": null\n        }\n      }\n    ],\n    \"color\": \"default\"\n  }\n}\n",
-	}).Output(func(e *Block, b *CodeBuilder) {
-		// TODO
 	})
 
 	var paragraph *SimpleObject
@@ -736,7 +749,7 @@ func TestBlock(t *testing.T) {
 		Type:        "array of rich text objects",
 		Description: "The rich text displayed in the paragraph block.",
 	}).Output(func(e *Parameter, b *CodeBuilder) {
-		paragraph.AddFields(b.NewField(e, jen.Id("RichTextArray")))
+		paragraph.AddFields(b.NewField(e, jen.Id("RichTextArray"), OmitEmpty))
 	})
 	c.ExpectParameter(&Parameter{
 		Property:    "color",
@@ -780,14 +793,14 @@ func TestBlock(t *testing.T) {
 		Type:        "array of rich text objects",
 		Description: "A caption, if provided, for the PDF block.",
 	}).Output(func(e *Parameter, b *CodeBuilder) {
-		pdf.AddFields(b.NewField(e, jen.Id("RichTextArray")))
+		pdf.AddFields(b.NewField(e, jen.Id("RichTextArray"), OmitEmpty))
 	})
 	c.ExpectParameter(&Parameter{
 		Property:    "type",
 		Type:        "\"external\"  \n  \n\"file\"",
 		Description: "A constant string representing the type of PDF. file indicates a Notion-hosted file, and external represents a third-party link.",
 	}).Output(func(e *Parameter, b *CodeBuilder) {
-		pdf.AddFields(b.NewField(e, jen.String()))
+		pdf.AddFields(b.NewField(e, jen.String(), OmitEmpty))
 	})
 	c.ExpectParameter(&Parameter{
 		Property:    "external  \n  \nfile",
@@ -806,44 +819,42 @@ func TestBlock(t *testing.T) {
 		// TODO
 	})
 
+	var quote *SimpleObject
+
 	c.ExpectBlock(&Block{
 		Kind: "Heading",
 		Text: "Quote",
 	}).Output(func(e *Block, b *CodeBuilder) {
-		// TODO
+		quote = block.AddPayloadField("quote", e.Text, WithPayloadObject(b))
 	})
 	c.ExpectBlock(&Block{
 		Kind: "Paragraph",
 		Text: "Quote block objects contain the following information within the quote property:",
-	}).Output(func(e *Block, b *CodeBuilder) {
-		// TODO
 	})
 	c.ExpectParameter(&Parameter{
 		Property:    "rich_text",
 		Type:        "array of rich text objects",
 		Description: "The rich text displayed in the quote block.",
 	}).Output(func(e *Parameter, b *CodeBuilder) {
-		// TODO
+		quote.AddFields(b.NewField(e, jen.Id("RichTextArray"), OmitEmpty))
 	})
 	c.ExpectParameter(&Parameter{
 		Property:    "color",
 		Type:        "string (enum)",
 		Description: "The color of the block. Possible values are:  \n  \n- \"blue\"\n- \"blue_background\"\n- \"brown\"\n- \"brown_background\"\n- \"default\"\n- \"gray\"\n- \"gray_background\"\n- \"green\"\n- \"green_background\"\n- \"orange\"\n- \"orange_background\"\n- \"yellow\"\n- \"green\"\n- \"pink\"\n- \"pink_background\"\n- \"purple\"\n- \"purple_background\"\n- \"red\"\n- \"red_background\"\n- \"yellow_background\"",
 	}).Output(func(e *Parameter, b *CodeBuilder) {
-		// TODO
+		quote.AddFields(b.NewField(e, jen.String(), OmitEmpty))
 	})
 	c.ExpectParameter(&Parameter{
 		Property:    "children",
 		Type:        "array of block objects",
 		Description: "The nested child blocks, if any, of the quote block.",
 	}).Output(func(e *Parameter, b *CodeBuilder) {
-		// TODO
+		quote.AddFields(b.NewField(e, jen.Index().Id("Block"), OmitEmpty))
 	})
 	c.ExpectBlock(&Block{
 		Kind: "FencedCodeBlock",
 		Text: "{\n\t//...other keys excluded\n\t\"type\": \"quote\",\n   //...other keys excluded\n   \"quote\": {\n   \t\"rich_text\": [{\n      \"type\": \"text\",\n      \"text\": {\n        \"content\": \"To be or not to be...\",\n        \"link\": null\n      },\n    \t//...other keys excluded\n    }],\n    //...other keys excluded\n    \"color\": \"default\"\n   }\n}\n",
-	}).Output(func(e *Block, b *CodeBuilder) {
-		// TODO
 	})
 
 	{
@@ -920,165 +931,149 @@ func TestBlock(t *testing.T) {
 		Text: "🚧The API does not supported updating synced block content.",
 	})
 
+	var table *SimpleObject
+
 	c.ExpectBlock(&Block{
 		Kind: "Heading",
 		Text: "Table",
 	}).Output(func(e *Block, b *CodeBuilder) {
-		// TODO
+		table = block.AddPayloadField("table", e.Text, WithPayloadObject(b))
 	})
 	c.ExpectBlock(&Block{
 		Kind: "Paragraph",
 		Text: "Table block objects are parent blocks for table row children. Table block objects contain the following fields within the table property:",
-	}).Output(func(e *Block, b *CodeBuilder) {
-		// TODO
 	})
 	c.ExpectParameter(&Parameter{
 		Property:    "table_width",
 		Type:        "integer",
 		Description: "The number of columns in the table.  \n  \nNote that this cannot be changed via the public API once a table is created.",
 	}).Output(func(e *Parameter, b *CodeBuilder) {
-		// TODO
+		table.AddFields(b.NewField(e, jen.Int(), OmitEmpty))
 	})
 	c.ExpectParameter(&Parameter{
 		Property:    "has_column_header",
 		Type:        "boolean",
 		Description: "Whether the table has a column header. If true, then the first row in the table appears visually distinct from the other rows.",
 	}).Output(func(e *Parameter, b *CodeBuilder) {
-		// TODO
+		// UpdateBlock で指定しない場合に見出しを解除しないよう、is_toggleable と同様にポインタとする
+		table.AddFields(b.NewField(e, jen.Op("*").Bool(), OmitEmpty))
 	})
 	c.ExpectParameter(&Parameter{
 		Property:    "has_row_header",
 		Type:        "boolean",
 		Description: "Whether the table has a header row. If true, then the first column in the table appears visually distinct from the other columns.",
 	}).Output(func(e *Parameter, b *CodeBuilder) {
-		// TODO
+		table.AddFields(b.NewField(e, jen.Op("*").Bool(), OmitEmpty))
+		table.AddFields(b.NewField(&Parameter{Property: "children", Description: UNDOCUMENTED}, jen.Index().Id("Block"), OmitEmpty))
 	})
 	c.ExpectBlock(&Block{
 		Kind: "FencedCodeBlock",
 		Text: "{\n  //...other keys excluded\n  \"type\": \"table\",\n  \"table\": {\n    \"table_width\": 2,\n    \"has_column_header\": false,\n    \"has_row_header\": false\n  }\n}\n",
-	}).Output(func(e *Block, b *CodeBuilder) {
-		// TODO
 	})
 	c.ExpectBlock(&Block{
 		Kind: "Blockquote",
 		Text: "🚧 table_width can only be set when the table is first created.Note that the number of columns in a table can only be set when the table is first created. Calls to the Update block endpoint to update table_width fail.",
-	}).Output(func(e *Block, b *CodeBuilder) {
-		// TODO
 	})
 
+	var tableRow *SimpleObject
+
 	c.ExpectBlock(&Block{
 		Kind: "Heading",
 		Text: "Table rows",
 	}).Output(func(e *Block, b *CodeBuilder) {
-		// TODO
+		tableRow = block.AddPayloadField("table_row", e.Text, WithPayloadObject(b))
 	})
 	c.ExpectBlock(&Block{
 		Kind: "Paragraph",
 		Text: "Follow these steps to fetch the table_rows of a table:",
-	}).Output(func(e *Block, b *CodeBuilder) {
-		// TODO
 	})
 	c.ExpectBlock(&Block{
 		Kind: "List",
 		Text: "Get the table ID from a query to Retrieve block children for the parent page.Get the table_rows from a query to Retrieve block children for the table.",
-	}).Output(func(e *Block, b *CodeBuilder) {
-		// TODO
 	})
 	c.ExpectBlock(&Block{
 		Kind: "Paragraph",
 		Text: "A table_row block object contains the following fields within the table_row property:",
-	}).Output(func(e *Block, b *CodeBuilder) {
-		// TODO
 	})
 	c.ExpectParameter(&Parameter{
 		Property:    "cells",
 		Type:        "array of array of rich text objects",
 		Description: "An array of cell contents in horizontal display order. Each cell is an array of rich text objects.",
 	}).Output(func(e *Parameter, b *CodeBuilder) {
-		// TODO
+		tableRow.AddFields(b.NewField(e, jen.Index().Id("RichTextArray"), OmitEmpty))
 	})
 	c.ExpectBlock(&Block{
 		Kind: "FencedCodeBlock",
 		Text: "{\n  //...other keys excluded\n  \"type\": \"table_row\",\n  \"table_row\": {\n    \"cells\": [\n      [\n        {\n          \"type\": \"text\",\n          \"text\": {\n            \"content\": \"column 1 content\",\n            \"link\": null\n          },\n          \"annotations\": {\n            \"bold\": false,\n            \"italic\": false,\n            \"strikethrough\": false,\n            \"underline\": false,\n            \"code\": false,\n            \"color\": \"default\"\n          },\n          \"plain_text\": \"column 1 content\",\n          \"href\": null\n        }\n      ],\n      [\n        {\n          \"type\": \"text\",\n          \"text\": {\n            \"content\": \"column 2 content\",\n            \"link\": null\n          },\n          \"annotations\": {\n            \"bold\": false,\n            \"italic\": false,\n            \"strikethrough\": false,\n            \"underline\": false,\n            \"code\": false,\n            \"color\": \"default\"\n          },\n          \"plain_text\": \"column 2 content\",\n          \"href\": null\n        }\n      ],\n      [\n        {\n          \"type\": \"text\",\n          \"text\": {\n            \"content\": \"column 3 content\",\n            \"link\": null\n          },\n          \"annotations\": {\n            \"bold\": false,\n            \"italic\": false,\n            \"strikethrough\": false,\n            \"underline\": false,\n            \"code\": false,\n            \"color\": \"default\"\n          },\n          \"plain_text\": \"column 3 content\",\n          \"href\": null\n        }\n      ]\n    ]\n  }\n}\n",
-	}).Output(func(e *Block, b *CodeBuilder) {
-		// TODO
 	})
 	c.ExpectBlock(&Block{
 		Kind: "Blockquote",
 		Text: "📘When creating a table block via the Append block children endpoint, the table must have at least one table_row whose cells array has the same length as the table_width.",
-	}).Output(func(e *Block, b *CodeBuilder) {
-		// TODO
 	})
 
+	var tableOfContents *SimpleObject
+
 	c.ExpectBlock(&Block{
 		Kind: "Heading",
 		Text: "Table of contents",
 	}).Output(func(e *Block, b *CodeBuilder) {
-		// TODO
+		tableOfContents = block.AddPayloadField("table_of_contents", e.Text, WithPayloadObject(b))
 	})
 	c.ExpectBlock(&Block{
 		Kind: "Paragraph",
 		Text: "Table of contents block objects contain the following information within the table_of_contents property:",
-	}).Output(func(e *Block, b *CodeBuilder) {
-		// TODO
 	})
 	c.ExpectParameter(&Parameter{
 		Property:    "color",
 		Type:        "string (enum)",
 		Description: "The color of the block. Possible values are:  \n  \n- \"blue\"\n- \"blue_background\"\n- \"brown\"\n- \"brown_background\"\n- \"default\"\n- \"gray\"\n- \"gray_background\"\n- \"green\"\n- \"green_background\"\n- \"orange\"\n- \"orange_background\"\n- \"yellow\"\n- \"green\"\n- \"pink\"\n- \"pink_background\"\n- \"purple\"\n- \"purple_background\"\n- \"red\"\n- \"red_background\"\n- \"yellow_background\"",
 	}).Output(func(e *Parameter, b *CodeBuilder) {
-		// TODO
+		tableOfContents.AddFields(b.NewField(e, jen.String(), OmitEmpty))
 	})
 	c.ExpectBlock(&Block{
 		Kind: "FencedCodeBlock",
 		Text: "{\n  //...other keys excluded\n\t\"type\": \"table_of_contents\",\n  \"table_of_contents\": {\n  \t\"color\": \"default\"\n  }\n}\n",
-	}).Output(func(e *Block, b *CodeBuilder) {
-		// TODO
 	})
 
+	var template *SimpleObject
+
 	c.ExpectBlock(&Block{
 		Kind: "Heading",
 		Text: "Template",
 	}).Output(func(e *Block, b *CodeBuilder) {
-		// TODO
+		template = block.AddPayloadField("template", e.Text, WithPayloadObject(b))
 	})
 	c.ExpectBlock(&Block{
 		Kind: "Blockquote",
 		Text: "❗️ Deprecation NoticeAs of March 27, 2023 creation of template blocks will no longer be supported.",
-	}).Output(func(e *Block, b *CodeBuilder) {
-		// TODO
 	})
 	c.ExpectBlock(&Block{
 		Kind: "Paragraph",
 		Text: "Template blocks represent template buttons in the Notion UI.",
 	}).Output(func(e *Block, b *CodeBuilder) {
-		// TODO
+		template.AddComment(e.Text)
 	})
 	c.ExpectBlock(&Block{
 		Kind: "Paragraph",
 		Text: "Template block objects contain the following information within the template property:",
-	}).Output(func(e *Block, b *CodeBuilder) {
-		// TODO
 	})
 	c.ExpectParameter(&Parameter{
 		Property:    "rich_text",
 		Type:        "array of rich text objects",
 		Description: "The rich text displayed in the title of the template.",
 	}).Output(func(e *Parameter, b *CodeBuilder) {
-		// TODO
+		template.AddFields(b.NewField(e, jen.Id("RichTextArray")))
 	})
 	c.ExpectParameter(&Parameter{
 		Property:    "children",
 		Type:        "array of block objects",
 		Description: "The nested child blocks, if any, of the template block. These blocks are duplicated when the template block is used in the UI.",
 	}).Output(func(e *Parameter, b *CodeBuilder) {
-		// TODO
+		template.AddFields(b.NewField(e, jen.Index().Id("Block"), OmitEmpty))
 	})
 	c.ExpectBlock(&Block{
 		Kind: "FencedCodeBlock",
 		Text: "{\n  //...other keys excluded\n  \"template\": {\n    \"rich_text\": [\n      {\n        \"type\": \"text\",\n        \"text\": {\n          \"content\": \"Add a new to-do\",\n          \"link\": null\n        },\n        \"annotations\": {\n          //...other keys excluded\n        },\n        \"plain_text\": \"Add a new to-do\",\n        \"href\": null\n      }\n    ]\n  }\n}\n",
-	}).Output(func(e *Block, b *CodeBuilder) {
-		// TODO
 	})
 
 	{
@@ -1099,7 +1094,8 @@ func TestBlock(t *testing.T) {
 			Type:        "array of rich text objects",
 			Description: "The rich text displayed in the To do block.",
 		}).Output(func(e *Parameter, b *CodeBuilder) {
-			blockToDo.AddFields(b.NewField(e, jen.Id("RichTextArray")))
+			// UpdateBlock で checked だけを更新できるよう omitempty とする
+			blockToDo.AddFields(b.NewField(e, jen.Id("RichTextArray"), OmitEmpty))
 		})
 		c.ExpectParameter(&Parameter{
 			Property:    "checked",
@@ -1129,81 +1125,73 @@ func TestBlock(t *testing.T) {
 		Text: "{\n  //...other keys excluded\n  \"type\": \"to_do\",\n  \"to_do\": {\n    \"rich_text\": [{\n      \"type\": \"text\",\n      \"text\": {\n        \"content\": \"Finish Q3 goals\",\n        \"link\": null\n      }\n    }],\n    \"checked\": false,\n    \"color\": \"default\",\n    \"children\":[{\n      \"type\": \"paragraph\"\n      // ..other keys excluded\n    }]\n  }\n}\n",
 	})
 
+	var toggle *SimpleObject
+
 	c.ExpectBlock(&Block{
 		Kind: "Heading",
 		Text: "Toggle blocks",
 	}).Output(func(e *Block, b *CodeBuilder) {
-		// TODO
+		toggle = block.AddPayloadField("toggle", e.Text, WithPayloadObject(b))
 	})
 	c.ExpectBlock(&Block{
 		Kind: "Paragraph",
 		Text: "Toggle block objects contain the following information within the toggle property:",
-	}).Output(func(e *Block, b *CodeBuilder) {
-		// TODO
 	})
 	c.ExpectParameter(&Parameter{
 		Property:    "rich_text",
 		Type:        "array of rich text objects",
 		Description: "The rich text displayed in the Toggle block.",
 	}).Output(func(e *Parameter, b *CodeBuilder) {
-		// TODO
+		toggle.AddFields(b.NewField(e, jen.Id("RichTextArray"), OmitEmpty))
 	})
 	c.ExpectParameter(&Parameter{
 		Property:    "color",
 		Type:        "string (enum)",
 		Description: "The color of the block. Possible values are:  \n  \n- \"blue\"\n- \"blue_background\"\n- \"brown\"\n- \"brown_background\"\n- \"default\"\n- \"gray\"\n- \"gray_background\"\n- \"green\"\n- \"green_background\"\n- \"orange\"\n- \"orange_background\"\n- \"yellow\"\n- \"green\"\n- \"pink\"\n- \"pink_background\"\n- \"purple\"\n- \"purple_background\"\n- \"red\"\n- \"red_background\"\n- \"yellow_background\"",
 	}).Output(func(e *Parameter, b *CodeBuilder) {
-		// TODO
+		toggle.AddFields(b.NewField(e, jen.String(), OmitEmpty))
 	})
 	c.ExpectParameter(&Parameter{
 		Property:    "children",
 		Type:        "array of block objects",
 		Description: "The nested child blocks, if any, of the Toggle block.",
 	}).Output(func(e *Parameter, b *CodeBuilder) {
-		// TODO
+		toggle.AddFields(b.NewField(e, jen.Index().Id("Block"), OmitEmpty))
 	})
 	c.ExpectBlock(&Block{
 		Kind: "FencedCodeBlock",
 		Text: "{\n  //...other keys excluded\n  \"type\": \"toggle\",\n  \"toggle\": {\n    \"rich_text\": [{\n      \"type\": \"text\",\n      \"text\": {\n        \"content\": \"Additional project details\",\n        \"link\": null\n      }\n      //...other keys excluded\n    }],\n    \"color\": \"default\",\n    \"children\":[{\n      \"type\": \"paragraph\"\n      // ..other keys excluded\n    }]\n  }\n}\n",
-	}).Output(func(e *Block, b *CodeBuilder) {
-		// TODO
 	})
 
 	c.ExpectBlock(&Block{
 		Kind: "Heading",
 		Text: "Video",
-	}).Output(func(e *Block, b *CodeBuilder) {
-		// TODO
 	})
 	c.ExpectBlock(&Block{
 		Kind: "Paragraph",
 		Text: "Video block objects contain a file object detailing information about the image.",
 	}).Output(func(e *Block, b *CodeBuilder) {
-		// TODO
+		block.AddPayloadField("video", e.Text, WithType(jen.Op("*").Id("File")))
 	})
 	c.ExpectBlock(&Block{
 		Kind: "FencedCodeBlock",
 		Text: "{\n  \"type\": \"video\",\n  //...other keys excluded\n  \"video\": {\n    \"type\": \"external\",\n    \"external\": {\n \t  \t\"url\": \"https://companywebsite.com/files/video.mp4\"\n    }\n  }\n}\n",
-	}).Output(func(e *Block, b *CodeBuilder) {
-		// TODO
 	})
 
 	c.ExpectBlock(&Block{
 		Kind: "Heading",
 		Text: "Supported video types",
-	}).Output(func(e *Block, b *CodeBuilder) {
-		// TODO
 	})
 	c.ExpectBlock(&Block{
 		Kind: "List",
 		Text: ".amv.asf.avi.f4v.flv.gifv.mkv.mov.mpg.mpeg.mpv.mp4.m4v.qt.wmvYouTube video links that include embed or watch.E.g. https://www.youtube.com/watch?v=[id], https://www.youtube.com/embed/[id]",
-	}).Output(func(e *Block, b *CodeBuilder) {
-		// TODO
 	})
 	c.ExpectBlock(&Block{
 		Kind: "Blockquote",
 		Text: "📘Vimeo video links are not currently supported by the video block type. However, they can be embedded in Notion pages using the embed block type. See Embed for more information.",
-	}).Output(func(e *Block, b *CodeBuilder) {
-		// TODO
+	})
+
+	c.RequestBuilderForUndocumented(func(b *CodeBuilder) {
+		block.AddPayloadField("audio", UNDOCUMENTED, WithType(jen.Op("*").Id("File")))
 	})
 }
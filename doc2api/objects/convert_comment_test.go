@@ -0,0 +1,102 @@
+package objects_test
+
+import (
+	"testing"
+
+	"github.com/dave/jennifer/jen"
+	. "github.com/psyark/notion/doc2api/objects"
+)
+
+func TestComment(t *testing.T) {
+	t.Parallel()
+
+	c := converter.FetchDocument("https://developers.notion.com/reference/comment-object")
+
+	var comment *SimpleObject
+
+	c.ExpectBlock(&Block{
+		Kind: "Paragraph",
+		Text: "The Comment object represents a comment on a Notion page or block. Comments can be viewed or created by an integration with access to the page/block and the correct capabilities. Please see the Capabilities guide for more information on which capabilities an integration needs to interact with comments.",
+	}).Output(func(e *Block, b *CodeBuilder) {
+		comment = b.AddSimpleObject("Comment", e.Text)
+	})
+
+	c.ExpectBlock(&Block{
+		Kind: "FencedCodeBlock",
+		Text: "{\n  \"object\": \"comment\",\n  \"id\": \"7a793800-3e55-4d5e-8009-2261de026179\",\n  \"parent\": {\n    \"type\": \"page_id\",\n    \"page_id\": \"5c6a2821-6bb1-4a7e-b6e1-c50111515c3d\"\n  },\n  \"discussion_id\": \"f4be6752-a539-4da2-a8a9-c3953e13bc0b\",\n  \"created_time\": \"2022-07-15T21:17:00.000Z\",\n  \"last_edited_time\": \"2022-07-15T21:17:00.000Z\",\n  \"created_by\": {\n    \"object\": \"user\",\n    \"id\": \"e450a39e-9051-4d36-bc4e-8581611fc592\"\n  },\n  \"rich_text\": [\n    {\n      \"type\": \"text\",\n      \"text\": {\n        \"content\": \"Hello world\",\n        \"link\": null\n      },\n      \"annotations\": {\n        \"bold\": false,\n        \"italic\": false,\n        \"strikethrough\": false,\n        \"underline\": false,\n        \"code\": false,\n        \"color\": \"default\"\n      },\n      \"plain_text\": \"Hello world\",\n      \"href\": null\n    }\n  ]\n}",
+	}).Output(func(e *Block, b *CodeBuilder) {
+		converter.AddUnmarshalTest("Comment", e.Text)
+	})
+
+	c.ExpectBlock(&Block{Kind: "Heading", Text: "Object properties"})
+	c.ExpectBlock(&Block{Kind: "Blockquote", Text: "📘Properties marked with an \\* are available to integrations with any capabilities. Other properties require read content capabilities in order to be returned from the Notion API. For more information on integration capabilities, see the capabilities guide."})
+
+	c.ExpectParameter(&Parameter{
+		Property:     "object",
+		Type:         "string",
+		Description:  `Always "comment"`,
+		ExampleValue: `"comment"`,
+	}).Output(func(e *Parameter, b *CodeBuilder) {
+		comment.AddFields(converter.NewDiscriminatorField(e))
+	})
+	c.ExpectParameter(&Parameter{
+		Property:     "id",
+		Type:         "string (UUIDv4)",
+		Description:  "Unique identifier of the comment.",
+		ExampleValue: `"ce18f8c6-ef2a-427f-b416-43531fc7c117"`,
+	}).Output(func(e *Parameter, b *CodeBuilder) {
+		comment.AddFields(b.NewField(e, UUID))
+	})
+	c.ExpectParameter(&Parameter{
+		Property:     "parent",
+		Type:         "object",
+		Description:  "Information about the comment's parent. See Parent object.",
+		ExampleValue: `{ "type": "block_id", "block_id": "5d4ca33c-d6b7-4675-93d9-84b70af45d1c" }`,
+	}).Output(func(e *Parameter, b *CodeBuilder) {
+		comment.AddFields(b.NewField(e, jen.Id("Parent")))
+	})
+	c.ExpectParameter(&Parameter{
+		Property:     "discussion_id",
+		Type:         "string (UUIDv4)",
+		Description:  "Unique identifier of the discussion thread that the comment is associated with. See our guide on working with comments.",
+		ExampleValue: `"ce18f8c6-ef2a-427f-b416-43531fc7c117"`,
+	}).Output(func(e *Parameter, b *CodeBuilder) {
+		comment.AddFields(b.NewField(e, UUID))
+	})
+	c.ExpectParameter(&Parameter{
+		Property:     "created_time",
+		Type:         "string (ISO 8601 date time)",
+		Description:  "Date and time when this comment was created. Formatted as an ISO 8601 date time string.",
+		ExampleValue: `"2022-07-15T21:46:00.000Z"`,
+	}).Output(func(e *Parameter, b *CodeBuilder) {
+		comment.AddFields(b.NewField(e, jen.Id("ISO8601String")))
+	})
+	c.ExpectParameter(&Parameter{
+		Property:     "last_edited_time",
+		Type:         "string (ISO 8601 date time)",
+		Description:  "Date and time when this comment was updated. Formatted as an ISO 8601 date time string.",
+		ExampleValue: `"2022-07-15T21:46:00.000Z"`,
+	}).Output(func(e *Parameter, b *CodeBuilder) {
+		comment.AddFields(b.NewField(e, jen.Id("ISO8601String")))
+	})
+	c.ExpectParameter(&Parameter{
+		Property:     "created_by",
+		Type:         "Partial User",
+		Description:  "User who created the comment.",
+		ExampleValue: `{"object": "user","id": "e450a39e-9051-4d36-bc4e-8581611fc592"}`,
+	}).Output(func(e *Parameter, b *CodeBuilder) {
+		comment.AddFields(b.NewField(e, jen.Id("User")))
+	})
+	c.ExpectParameter(&Parameter{
+		Property:     "rich_text",
+		Type:         "Rich text object",
+		Description:  "Content of the comment, which supports rich text formatting, links, and mentions.",
+		ExampleValue: "[\n  {\n    \"type\": \"text\",\n    \"text\": {\n      \"content\": \"Hello world\",\n      \"link\": null\n    },\n    \"annotations\": {\n      \"bold\": false,\n      \"italic\": false,\n      \"strikethrough\": false,\n      \"underline\": false,\n      \"code\": false,\n      \"color\": \"default\"\n    },\n    \"plain_text\": \"Hello world\",\n    \"href\": null\n  }\n]",
+	}).Output(func(e *Parameter, b *CodeBuilder) {
+		comment.AddFields(b.NewField(e, jen.Id("RichTextArray")))
+	})
+
+	c.RequestBuilderForUndocumented(func(b *CodeBuilder) {
+		comment.AddFields(UndocumentedRequestID(b))
+	})
+}
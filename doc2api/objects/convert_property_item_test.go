@@ -533,6 +533,8 @@ func TestPropertyItem(t *testing.T) {
 	})
 
 	c.RequestBuilderForUndocumented(func(b *CodeBuilder) {
+		propertyItem.AddPayloadField("unique_id", UNDOCUMENTED, WithType(jen.Op("*").Id("PropertyValueUniqueId")))
+		propertyItem.AddPayloadField("button", UNDOCUMENTED, WithEmptyStructRef())
 		propertyItem.AddFields(UndocumentedRequestID(b))
 	})
 }
@@ -176,4 +176,8 @@ func TestUser(t *testing.T) {
 	}).Output(func(e *Parameter, b *CodeBuilder) {
 		bot.AddFields(b.NewField(e, jen.String(), OmitEmpty))
 	})
+
+	c.RequestBuilderForUndocumented(func(b *CodeBuilder) {
+		user.AddFields(UndocumentedRequestID(b))
+	})
 }
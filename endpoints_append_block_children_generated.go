@@ -36,7 +36,8 @@ _Note: Each Public API endpoint can return several possible error codes. To see
 func (c *Client) AppendBlockChildren(ctx context.Context, block_id uuid.UUID, params AppendBlockChildrenParams, options ...CallOption) (*Pagination[Block], error) {
 	return call(
 		ctx,
-		c.accessToken,
+		c,
+		"AppendBlockChildren",
 		http.MethodPatch,
 		fmt.Sprintf("/v1/blocks/%v/children", block_id),
 		params,
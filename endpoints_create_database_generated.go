@@ -30,7 +30,8 @@ _Note: Each Public API endpoint can return several possible error codes. See the
 func (c *Client) CreateDatabase(ctx context.Context, params CreateDatabaseParams, options ...CallOption) (*Database, error) {
 	return call(
 		ctx,
-		c.accessToken,
+		c,
+		"CreateDatabase",
 		http.MethodPost,
 		"/v1/databases",
 		params,
@@ -36,7 +36,8 @@ Each Public API endpoint can return several possible error codes. See the [Error
 func (c *Client) CreatePage(ctx context.Context, params CreatePageParams, options ...CallOption) (*Page, error) {
 	return call(
 		ctx,
-		c.accessToken,
+		c,
+		"CreatePage",
 		http.MethodPost,
 		"/v1/pages",
 		params,
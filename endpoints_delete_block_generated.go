@@ -30,7 +30,8 @@ _Note: Each Public API endpoint can return several possible error codes. See the
 func (c *Client) DeleteBlock(ctx context.Context, block_id uuid.UUID, options ...CallOption) (*Block, error) {
 	return call(
 		ctx,
-		c.accessToken,
+		c,
+		"DeleteBlock",
 		http.MethodDelete,
 		fmt.Sprintf("/v1/blocks/%v", block_id),
 		nil,
@@ -136,7 +136,8 @@ _Note: Each Public API endpoint can return several possible error codes. See the
 func (c *Client) QueryDatabase(ctx context.Context, database_id uuid.UUID, params QueryDatabaseParams, options ...CallOption) (*Pagination[Page], error) {
 	return call(
 		ctx,
-		c.accessToken,
+		c,
+		"QueryDatabase",
 		http.MethodPost,
 		fmt.Sprintf("/v1/databases/%v/query", database_id),
 		params,
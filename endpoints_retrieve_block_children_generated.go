@@ -35,14 +35,29 @@ Returns a 400 or 429 HTTP response if the request exceeds the [request limits](r
 
 _Note: Each Public API endpoint can return several possible error codes. See the [Error codes section](https://developers.notion.com/reference/status-codes#error-codes) of the Status codes documentation for more information._
 */
-func (c *Client) RetrieveBlockChildren(ctx context.Context, block_id uuid.UUID, options ...CallOption) (*Pagination[Block], error) {
+func (c *Client) RetrieveBlockChildren(ctx context.Context, block_id uuid.UUID, params RetrieveBlockChildrenParams, options ...CallOption) (*Pagination[Block], error) {
 	return call(
 		ctx,
-		c.accessToken,
+		c,
+		"RetrieveBlockChildren",
 		http.MethodGet,
 		fmt.Sprintf("/v1/blocks/%v/children", block_id),
-		nil,
+		params,
 		accessValue[*Pagination[Block]],
 		options...,
 	)
 }
+
+type RetrieveBlockChildrenParams map[string]any
+
+// If supplied, this endpoint will return a page of results starting after the cursor provided. If not supplied, this endpoint will return the first page of results.
+func (p RetrieveBlockChildrenParams) StartCursor(start_cursor string) RetrieveBlockChildrenParams {
+	p["start_cursor"] = start_cursor
+	return p
+}
+
+// The number of items from the full list desired in the response. Maximum: 100
+func (p RetrieveBlockChildrenParams) PageSize(page_size int) RetrieveBlockChildrenParams {
+	p["page_size"] = page_size
+	return p
+}
@@ -57,7 +57,8 @@ Each Public API endpoint can return several possible error codes. See the [Error
 func (c *Client) RetrieveDatabase(ctx context.Context, database_id uuid.UUID, options ...CallOption) (*Database, error) {
 	return call(
 		ctx,
-		c.accessToken,
+		c,
+		"RetrieveDatabase",
 		http.MethodGet,
 		fmt.Sprintf("/v1/databases/%v", database_id),
 		nil,
@@ -53,7 +53,8 @@ _Note: Each Public API endpoint can return several possible error codes. See the
 func (c *Client) RetrievePage(ctx context.Context, page_id uuid.UUID, options ...CallOption) (*Page, error) {
 	return call(
 		ctx,
-		c.accessToken,
+		c,
+		"RetrievePage",
 		http.MethodGet,
 		fmt.Sprintf("/v1/pages/%v", page_id),
 		nil,
@@ -73,7 +73,8 @@ _Note: Each Public API endpoint can return several possible error codes. See the
 func (c *Client) RetrievePagePropertyItem(ctx context.Context, page_id uuid.UUID, property_id string, options ...CallOption) (PropertyItemOrPropertyItemPagination, error) {
 	return call(
 		ctx,
-		c.accessToken,
+		c,
+		"RetrievePagePropertyItem",
 		http.MethodGet,
 		fmt.Sprintf("/v1/pages/%v/properties/%v", page_id, property_id),
 		nil,
@@ -83,3 +84,17 @@ func (c *Client) RetrievePagePropertyItem(ctx context.Context, page_id uuid.UUID
 		options...,
 	)
 }
+
+type RetrievePagePropertyItemParams map[string]any
+
+// For paginated properties. The max page size is 100 items.
+func (p RetrievePagePropertyItemParams) PageSize(page_size int) RetrievePagePropertyItemParams {
+	p["page_size"] = page_size
+	return p
+}
+
+// For paginated properties.
+func (p RetrievePagePropertyItemParams) StartCursor(start_cursor string) RetrievePagePropertyItemParams {
+	p["start_cursor"] = start_cursor
+	return p
+}
@@ -30,7 +30,8 @@ Each Public API endpoint can return several possible error codes. See the [Error
 func (c *Client) SearchByTitle(ctx context.Context, params SearchByTitleParams, options ...CallOption) (*Pagination[PageOrDatabase], error) {
 	return call(
 		ctx,
-		c.accessToken,
+		c,
+		"SearchByTitle",
 		http.MethodPost,
 		"/v1/search",
 		params,
@@ -60,7 +60,8 @@ Each Public API endpoint can return several possible error codes. See the [Error
 func (c *Client) UpdateDatabase(ctx context.Context, database_id uuid.UUID, params UpdateDatabaseParams, options ...CallOption) (*Database, error) {
 	return call(
 		ctx,
-		c.accessToken,
+		c,
+		"UpdateDatabase",
 		http.MethodPatch,
 		fmt.Sprintf("/v1/databases/%v", database_id),
 		params,
@@ -37,7 +37,8 @@ Each Public API endpoint can return several possible error codes. See the [Error
 func (c *Client) UpdatePageProperties(ctx context.Context, page_id uuid.UUID, params UpdatePagePropertiesParams, options ...CallOption) (*Page, error) {
 	return call(
 		ctx,
-		c.accessToken,
+		c,
+		"UpdatePageProperties",
 		http.MethodPatch,
 		fmt.Sprintf("/v1/pages/%v", page_id),
 		params,
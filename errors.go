@@ -0,0 +1,143 @@
+package notion
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+)
+
+// Notion APIが返すエラーコード
+// https://developers.notion.com/reference/status-codes
+const (
+	ErrorCodeInvalidJSON                   = "invalid_json"
+	ErrorCodeInvalidRequestURL             = "invalid_request_url"
+	ErrorCodeInvalidRequest                = "invalid_request"
+	ErrorCodeInvalidGrant                  = "invalid_grant"
+	ErrorCodeValidationError               = "validation_error"
+	ErrorCodeMissingVersion                = "missing_version"
+	ErrorCodeUnauthorized                  = "unauthorized"
+	ErrorCodeRestrictedResource            = "restricted_resource"
+	ErrorCodeObjectNotFound                = "object_not_found"
+	ErrorCodeConflictError                 = "conflict_error"
+	ErrorCodeRateLimited                   = "rate_limited"
+	ErrorCodeInternalServerError           = "internal_server_error"
+	ErrorCodeBadGateway                    = "bad_gateway"
+	ErrorCodeServiceUnavailable            = "service_unavailable"
+	ErrorCodeDatabaseConnectionUnavailable = "database_connection_unavailable"
+	ErrorCodeGatewayTimeout                = "gateway_timeout"
+)
+
+// errors.Is で Error を分類するためのセンチネルエラー
+var (
+	ErrNotFound     = errors.New("notion: not found")
+	ErrRateLimited  = errors.New("notion: rate limited")
+	ErrUnauthorized = errors.New("notion: unauthorized")
+	ErrConflict     = errors.New("notion: conflict")
+	ErrValidation   = errors.New("notion: validation error")
+)
+
+// Error はAPIが200以外のステータスを返した場合のエラーです
+// https://developers.notion.com/reference/errors
+//
+// ボディがエラーオブジェクトでない場合も、Status にはHTTPステータスが、Message にはボディが設定されます。
+type Error struct {
+	Object    string `json:"object"`
+	Status    int    `json:"status"`
+	Code      string `json:"code"`
+	Message   string `json:"message"`
+	RequestId string `json:"request_id,omitempty"`
+	Method    string `json:"-"` // リクエストのHTTPメソッド
+	Path      string `json:"-"` // リクエストのパス（クエリ文字列を除く）
+}
+
+func (e Error) Error() string {
+	if e.Method == "" && e.Path == "" {
+		return fmt.Sprintf("(%v) %v", e.Code, e.Message)
+	}
+	return fmt.Sprintf("%v %v: (%v) %v", e.Method, e.Path, e.Code, e.Message)
+}
+
+// Is は、エラーコード（エラーコードが無い場合はHTTPステータス）に対応するセンチネルエラーについて true を返します
+func (e Error) Is(target error) bool {
+	switch e.Code {
+	case ErrorCodeObjectNotFound:
+		return target == ErrNotFound
+	case ErrorCodeRateLimited:
+		return target == ErrRateLimited
+	case ErrorCodeUnauthorized:
+		return target == ErrUnauthorized
+	case ErrorCodeConflictError:
+		return target == ErrConflict
+	case ErrorCodeValidationError:
+		return target == ErrValidation
+	case "":
+		switch e.Status {
+		case http.StatusNotFound:
+			return target == ErrNotFound
+		case http.StatusTooManyRequests:
+			return target == ErrRateLimited
+		case http.StatusUnauthorized:
+			return target == ErrUnauthorized
+		case http.StatusConflict:
+			return target == ErrConflict
+		}
+	}
+	return false
+}
+
+// TransportError は、レスポンスを受け取れなかった場合（接続の失敗やボディの読み取りの失敗など）のエラーです
+// Err には元のエラーが格納され、errors.Is や errors.As で参照できます
+type TransportError struct {
+	Method string
+	Path   string
+	Err    error
+}
+
+func (e *TransportError) Error() string {
+	return fmt.Sprintf("%v %v: %v", e.Method, e.Path, e.Err)
+}
+
+func (e *TransportError) Unwrap() error {
+	return e.Err
+}
+
+// Temporary は、リクエストを再送することで成功する可能性がある場合に true を返します
+// ネットワークエラーやタイムアウト、接続のリセットでは true を返し、
+// コンテキストのキャンセル、TLSや証明書の検証の失敗、不正なURLなど再送しても解消しないエラーでは false を返します
+func (e *TransportError) Temporary() bool {
+	err := e.Err
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		err = urlErr.Err // *url.Error 自体が net.Error を実装するため、原因のエラーで判定します
+	}
+
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) || isTLSError(err) {
+		return false
+	}
+	if errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, syscall.ECONNRESET) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// isTLSError は、err がTLSのハンドシェイクや証明書の検証の失敗によるものかを返します
+func isTLSError(err error) bool {
+	var (
+		certErr      *tls.CertificateVerificationError
+		alertErr     tls.AlertError
+		recordErr    tls.RecordHeaderError
+		authorityErr x509.UnknownAuthorityError
+		hostnameErr  x509.HostnameError
+		invalidErr   x509.CertificateInvalidError
+	)
+	return errors.As(err, &certErr) || errors.As(err, &alertErr) || errors.As(err, &recordErr) ||
+		errors.As(err, &authorityErr) || errors.As(err, &hostnameErr) || errors.As(err, &invalidErr)
+}
@@ -0,0 +1,106 @@
+package notion
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"syscall"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestError(t *testing.T) {
+	ctx := context.Background()
+	pageId := uuid.MustParse("c02fc1d3-db8b-45c5-a222-27595b15aea7")
+
+	t.Run("object", func(t *testing.T) {
+		requests := []*http.Request{}
+		stub := stubResponse(&requests, http.StatusNotFound, `{"object":"error","status":404,"code":"object_not_found","message":"Could not find page.","request_id":"8a6c0a3f-0a2f-4c5e-9d3a-6d3b6a7f1e5c"}`)
+
+		_, err := (&Client{}).RetrievePage(ctx, pageId, stub)
+		assert.ErrorIs(t, err, ErrNotFound)
+		assert.NotErrorIs(t, err, ErrValidation)
+
+		var apiErr Error
+		if assert.ErrorAs(t, err, &apiErr) {
+			assert.Equal(t, Error{
+				Object:    "error",
+				Status:    http.StatusNotFound,
+				Code:      ErrorCodeObjectNotFound,
+				Message:   "Could not find page.",
+				RequestId: "8a6c0a3f-0a2f-4c5e-9d3a-6d3b6a7f1e5c",
+				Method:    http.MethodGet,
+				Path:      "/v1/pages/c02fc1d3-db8b-45c5-a222-27595b15aea7",
+			}, apiErr)
+		}
+		assert.Equal(t, "GET /v1/pages/c02fc1d3-db8b-45c5-a222-27595b15aea7: (object_not_found) Could not find page.", err.Error())
+	})
+
+	t.Run("non-JSON", func(t *testing.T) {
+		requests := []*http.Request{}
+		stub := stubResponse(&requests, http.StatusTooManyRequests, `<html>Too Many Requests</html>`)
+
+		_, err := (&Client{}).RetrieveBlockChildren(ctx, pageId, RetrieveBlockChildrenParams{"page_size": 1}, stub)
+		assert.ErrorIs(t, err, ErrRateLimited)
+
+		var apiErr Error
+		if assert.ErrorAs(t, err, &apiErr) {
+			assert.Equal(t, http.StatusTooManyRequests, apiErr.Status)
+			assert.Equal(t, "<html>Too Many Requests</html>", apiErr.Message)
+			assert.Equal(t, "/v1/blocks/c02fc1d3-db8b-45c5-a222-27595b15aea7/children", apiErr.Path)
+		}
+	})
+
+	t.Run("transport", func(t *testing.T) {
+		stub := WithRoundTripper(roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			return nil, io.ErrUnexpectedEOF
+		}))
+
+		_, err := (&Client{}).RetrievePage(ctx, pageId, stub)
+		assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+
+		var transportErr *TransportError
+		if assert.True(t, errors.As(err, &transportErr)) {
+			assert.Equal(t, http.MethodGet, transportErr.Method)
+			assert.True(t, transportErr.Temporary())
+		}
+	})
+}
+
+func TestTransportErrorTemporary(t *testing.T) {
+	urlErr := func(err error) error {
+		return &url.Error{Op: "Get", URL: "https://api.notion.com/v1/users", Err: err}
+	}
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"unexpected EOF", io.ErrUnexpectedEOF, true},
+		{"connection reset", urlErr(&net.OpError{Op: "read", Net: "tcp", Err: os.NewSyscallError("read", syscall.ECONNRESET)}), true},
+		{"dial", urlErr(&net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}), true},
+		{"dns", urlErr(&net.DNSError{Err: "no such host", Name: "api.notion.com", IsTemporary: true}), true},
+		{"canceled", urlErr(context.Canceled), false},
+		{"deadline", urlErr(context.DeadlineExceeded), false},
+		{"certificate", urlErr(&tls.CertificateVerificationError{Err: x509.UnknownAuthorityError{}}), false},
+		{"hostname", urlErr(x509.HostnameError{Host: "api.notion.com"}), false},
+		{"not TLS", urlErr(tls.RecordHeaderError{Msg: "first record does not look like a TLS handshake"}), false},
+		{"scheme", urlErr(errors.New(`unsupported protocol scheme "ftp"`)), false},
+		{"bad URL", &url.Error{Op: "parse", URL: "://", Err: errors.New("missing protocol scheme")}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := &TransportError{Method: http.MethodGet, Path: "/v1/users", Err: tt.err}
+			assert.Equal(t, tt.want, err.Temporary())
+		})
+	}
+}
@@ -0,0 +1,493 @@
+package notion
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/google/uuid"
+)
+
+// F はデータベースクエリのフィルターを組み立てるための起点です
+//
+//	filter := notion.F.And(
+//		notion.F.Property("Done").Checkbox().Equals(true),
+//		notion.F.CreatedTime().PastWeek(),
+//	)
+var F FilterBuilder
+
+// FilterBuilder はフィルターを組み立てます。F を通して使用してください
+type FilterBuilder struct{}
+
+// And は全ての filters を満たす複合フィルターを返します
+func (FilterBuilder) And(filters ...Filter) Filter {
+	return Filter{And: filters}
+}
+
+// Or はいずれかの filters を満たす複合フィルターを返します
+func (FilterBuilder) Or(filters ...Filter) Filter {
+	return Filter{Or: filters}
+}
+
+// Property は名前またはIDが nameOrId のプロパティに対する条件を組み立てます
+func (FilterBuilder) Property(nameOrId string) PropertyFilterBuilder {
+	return PropertyFilterBuilder{property: nameOrId}
+}
+
+// Item はロールアップの Any・Every・None に渡す、各要素に対する条件を組み立てます
+func (FilterBuilder) Item() PropertyFilterBuilder {
+	return PropertyFilterBuilder{}
+}
+
+// CreatedTime はページの作成日時に対する条件を組み立てます
+func (FilterBuilder) CreatedTime() DateFilterBuilder {
+	return DateFilterBuilder{func(c FilterDate) Filter {
+		return Filter{Timestamp: "created_time", CreatedTime: &c}
+	}}
+}
+
+// LastEditedTime はページの最終更新日時に対する条件を組み立てます
+func (FilterBuilder) LastEditedTime() DateFilterBuilder {
+	return DateFilterBuilder{func(c FilterDate) Filter {
+		return Filter{Timestamp: "last_edited_time", LastEditedTime: &c}
+	}}
+}
+
+// PropertyFilterBuilder はプロパティの種類に応じた条件の組み立てを選択します
+type PropertyFilterBuilder struct {
+	property string
+}
+
+// Checkbox は checkbox プロパティの条件を組み立てます
+func (b PropertyFilterBuilder) Checkbox() CheckboxFilterBuilder {
+	return CheckboxFilterBuilder{func(c FilterCheckbox) Filter {
+		return Filter{Property: b.property, Checkbox: &c}
+	}}
+}
+
+// Date は date、created_time、last_edited_time プロパティの条件を組み立てます
+func (b PropertyFilterBuilder) Date() DateFilterBuilder {
+	return DateFilterBuilder{func(c FilterDate) Filter {
+		return Filter{Property: b.property, Date: &c}
+	}}
+}
+
+// Files は files プロパティの条件を組み立てます
+func (b PropertyFilterBuilder) Files() FilesFilterBuilder {
+	return FilesFilterBuilder{func(c FilterFiles) Filter {
+		return Filter{Property: b.property, Files: &c}
+	}}
+}
+
+// Formula は formula プロパティの条件を組み立てます
+func (b PropertyFilterBuilder) Formula() FormulaFilterBuilder {
+	return FormulaFilterBuilder{func(c FilterFormula) Filter {
+		return Filter{Property: b.property, Formula: &c}
+	}}
+}
+
+// MultiSelect は multi_select プロパティの条件を組み立てます
+func (b PropertyFilterBuilder) MultiSelect() MultiSelectFilterBuilder {
+	return MultiSelectFilterBuilder{func(c FilterMultiSelect) Filter {
+		return Filter{Property: b.property, MultiSelect: &c}
+	}}
+}
+
+// Number は number、unique_id プロパティの条件を組み立てます
+func (b PropertyFilterBuilder) Number() NumberFilterBuilder {
+	return NumberFilterBuilder{func(c FilterNumber) Filter {
+		return Filter{Property: b.property, Number: &c}
+	}}
+}
+
+// People は people、created_by、last_edited_by プロパティの条件を組み立てます
+func (b PropertyFilterBuilder) People() IdFilterBuilder {
+	return IdFilterBuilder{func(contains, doesNotContain *uuid.UUID, isEmpty, isNotEmpty bool) Filter {
+		return Filter{Property: b.property, People: &FilterPeople{Contains: contains, DoesNotContain: doesNotContain, IsEmpty: isEmpty, IsNotEmpty: isNotEmpty}}
+	}}
+}
+
+// Relation は relation プロパティの条件を組み立てます
+func (b PropertyFilterBuilder) Relation() IdFilterBuilder {
+	return IdFilterBuilder{func(contains, doesNotContain *uuid.UUID, isEmpty, isNotEmpty bool) Filter {
+		return Filter{Property: b.property, Relation: &FilterRelation{Contains: contains, DoesNotContain: doesNotContain, IsEmpty: isEmpty, IsNotEmpty: isNotEmpty}}
+	}}
+}
+
+// Text は title、rich_text、url、email、phone_number プロパティの条件を組み立てます
+func (b PropertyFilterBuilder) Text() TextFilterBuilder {
+	return TextFilterBuilder{func(c FilterRichText) Filter {
+		return Filter{Property: b.property, RichText: &c}
+	}}
+}
+
+// Rollup は rollup プロパティの条件を組み立てます
+func (b PropertyFilterBuilder) Rollup() RollupFilterBuilder {
+	return RollupFilterBuilder{func(c FilterRollup) Filter {
+		return Filter{Property: b.property, Rollup: &c}
+	}}
+}
+
+// Select は select プロパティの条件を組み立てます
+func (b PropertyFilterBuilder) Select() OptionFilterBuilder {
+	return OptionFilterBuilder{func(c FilterSelect) Filter {
+		return Filter{Property: b.property, Select: &c}
+	}}
+}
+
+// Status は status プロパティの条件を組み立てます
+func (b PropertyFilterBuilder) Status() OptionFilterBuilder {
+	return OptionFilterBuilder{func(c FilterSelect) Filter {
+		return Filter{Property: b.property, Status: &FilterStatus{Equals: c.Equals, DoesNotEqual: c.DoesNotEqual, IsEmpty: c.IsEmpty, IsNotEmpty: c.IsNotEmpty}}
+	}}
+}
+
+// CheckboxFilterBuilder はチェックボックスの条件を組み立てます
+type CheckboxFilterBuilder struct {
+	build func(FilterCheckbox) Filter
+}
+
+func (b CheckboxFilterBuilder) Equals(v bool) Filter {
+	return b.build(FilterCheckbox{Equals: &v})
+}
+func (b CheckboxFilterBuilder) DoesNotEqual(v bool) Filter {
+	return b.build(FilterCheckbox{DoesNotEqual: &v})
+}
+
+// DateFilterBuilder は日付の条件を組み立てます
+// 日付は "2006-01-02" 形式の日付、またはRFC3339形式の日時で指定します
+type DateFilterBuilder struct {
+	build func(FilterDate) Filter
+}
+
+func (b DateFilterBuilder) After(date string) Filter {
+	return b.build(FilterDate{After: date})
+}
+func (b DateFilterBuilder) Before(date string) Filter {
+	return b.build(FilterDate{Before: date})
+}
+func (b DateFilterBuilder) Equals(date string) Filter {
+	return b.build(FilterDate{Equals: date})
+}
+func (b DateFilterBuilder) OnOrAfter(date string) Filter {
+	return b.build(FilterDate{OnOrAfter: date})
+}
+func (b DateFilterBuilder) OnOrBefore(date string) Filter {
+	return b.build(FilterDate{OnOrBefore: date})
+}
+func (b DateFilterBuilder) IsEmpty() Filter {
+	return b.build(FilterDate{IsEmpty: true})
+}
+func (b DateFilterBuilder) IsNotEmpty() Filter {
+	return b.build(FilterDate{IsNotEmpty: true})
+}
+func (b DateFilterBuilder) NextMonth() Filter {
+	return b.build(FilterDate{NextMonth: &struct{}{}})
+}
+func (b DateFilterBuilder) NextWeek() Filter {
+	return b.build(FilterDate{NextWeek: &struct{}{}})
+}
+func (b DateFilterBuilder) NextYear() Filter {
+	return b.build(FilterDate{NextYear: &struct{}{}})
+}
+func (b DateFilterBuilder) PastMonth() Filter {
+	return b.build(FilterDate{PastMonth: &struct{}{}})
+}
+func (b DateFilterBuilder) PastWeek() Filter {
+	return b.build(FilterDate{PastWeek: &struct{}{}})
+}
+func (b DateFilterBuilder) PastYear() Filter {
+	return b.build(FilterDate{PastYear: &struct{}{}})
+}
+func (b DateFilterBuilder) ThisWeek() Filter {
+	return b.build(FilterDate{ThisWeek: &struct{}{}})
+}
+
+// FilesFilterBuilder はファイルの条件を組み立てます
+type FilesFilterBuilder struct {
+	build func(FilterFiles) Filter
+}
+
+func (b FilesFilterBuilder) IsEmpty() Filter {
+	return b.build(FilterFiles{IsEmpty: true})
+}
+func (b FilesFilterBuilder) IsNotEmpty() Filter {
+	return b.build(FilterFiles{IsNotEmpty: true})
+}
+
+// FormulaFilterBuilder は数式の結果の種類に応じた条件の組み立てを選択します
+type FormulaFilterBuilder struct {
+	build func(FilterFormula) Filter
+}
+
+func (b FormulaFilterBuilder) Checkbox() CheckboxFilterBuilder {
+	return CheckboxFilterBuilder{func(c FilterCheckbox) Filter { return b.build(FilterFormula{Checkbox: &c}) }}
+}
+func (b FormulaFilterBuilder) Date() DateFilterBuilder {
+	return DateFilterBuilder{func(c FilterDate) Filter { return b.build(FilterFormula{Date: &c}) }}
+}
+func (b FormulaFilterBuilder) Number() NumberFilterBuilder {
+	return NumberFilterBuilder{func(c FilterNumber) Filter { return b.build(FilterFormula{Number: &c}) }}
+}
+func (b FormulaFilterBuilder) String() TextFilterBuilder {
+	return TextFilterBuilder{func(c FilterRichText) Filter { return b.build(FilterFormula{String: &c}) }}
+}
+
+// MultiSelectFilterBuilder はマルチセレクトの条件を組み立てます
+type MultiSelectFilterBuilder struct {
+	build func(FilterMultiSelect) Filter
+}
+
+func (b MultiSelectFilterBuilder) Contains(name string) Filter {
+	return b.build(FilterMultiSelect{Contains: name})
+}
+func (b MultiSelectFilterBuilder) DoesNotContain(name string) Filter {
+	return b.build(FilterMultiSelect{DoesNotContain: name})
+}
+func (b MultiSelectFilterBuilder) IsEmpty() Filter {
+	return b.build(FilterMultiSelect{IsEmpty: true})
+}
+func (b MultiSelectFilterBuilder) IsNotEmpty() Filter {
+	return b.build(FilterMultiSelect{IsNotEmpty: true})
+}
+
+// NumberFilterBuilder は数値の条件を組み立てます
+type NumberFilterBuilder struct {
+	build func(FilterNumber) Filter
+}
+
+func (b NumberFilterBuilder) Equals(v float64) Filter {
+	return b.build(FilterNumber{Equals: &v})
+}
+func (b NumberFilterBuilder) DoesNotEqual(v float64) Filter {
+	return b.build(FilterNumber{DoesNotEqual: &v})
+}
+func (b NumberFilterBuilder) GreaterThan(v float64) Filter {
+	return b.build(FilterNumber{GreaterThan: &v})
+}
+func (b NumberFilterBuilder) GreaterThanOrEqualTo(v float64) Filter {
+	return b.build(FilterNumber{GreaterThanOrEqualTo: &v})
+}
+func (b NumberFilterBuilder) LessThan(v float64) Filter {
+	return b.build(FilterNumber{LessThan: &v})
+}
+func (b NumberFilterBuilder) LessThanOrEqualTo(v float64) Filter {
+	return b.build(FilterNumber{LessThanOrEqualTo: &v})
+}
+func (b NumberFilterBuilder) IsEmpty() Filter {
+	return b.build(FilterNumber{IsEmpty: true})
+}
+func (b NumberFilterBuilder) IsNotEmpty() Filter {
+	return b.build(FilterNumber{IsNotEmpty: true})
+}
+
+// IdFilterBuilder はユーザーまたはリレーション先のページのIDの条件を組み立てます
+type IdFilterBuilder struct {
+	build func(contains, doesNotContain *uuid.UUID, isEmpty, isNotEmpty bool) Filter
+}
+
+func (b IdFilterBuilder) Contains(id uuid.UUID) Filter {
+	return b.build(&id, nil, false, false)
+}
+func (b IdFilterBuilder) DoesNotContain(id uuid.UUID) Filter {
+	return b.build(nil, &id, false, false)
+}
+func (b IdFilterBuilder) IsEmpty() Filter {
+	return b.build(nil, nil, true, false)
+}
+func (b IdFilterBuilder) IsNotEmpty() Filter {
+	return b.build(nil, nil, false, true)
+}
+
+// TextFilterBuilder はテキストの条件を組み立てます
+type TextFilterBuilder struct {
+	build func(FilterRichText) Filter
+}
+
+func (b TextFilterBuilder) Contains(s string) Filter {
+	return b.build(FilterRichText{Contains: s})
+}
+func (b TextFilterBuilder) DoesNotContain(s string) Filter {
+	return b.build(FilterRichText{DoesNotContain: s})
+}
+func (b TextFilterBuilder) Equals(s string) Filter {
+	return b.build(FilterRichText{Equals: s})
+}
+func (b TextFilterBuilder) DoesNotEqual(s string) Filter {
+	return b.build(FilterRichText{DoesNotEqual: s})
+}
+func (b TextFilterBuilder) StartsWith(s string) Filter {
+	return b.build(FilterRichText{StartsWith: s})
+}
+func (b TextFilterBuilder) EndsWith(s string) Filter {
+	return b.build(FilterRichText{EndsWith: s})
+}
+func (b TextFilterBuilder) IsEmpty() Filter {
+	return b.build(FilterRichText{IsEmpty: true})
+}
+func (b TextFilterBuilder) IsNotEmpty() Filter {
+	return b.build(FilterRichText{IsNotEmpty: true})
+}
+
+// RollupFilterBuilder はロールアップの条件を組み立てます
+// Any・Every・None には F.Item() で組み立てた各要素に対する条件を渡します
+type RollupFilterBuilder struct {
+	build func(FilterRollup) Filter
+}
+
+func (b RollupFilterBuilder) Any(item Filter) Filter {
+	return b.build(FilterRollup{Any: &item})
+}
+func (b RollupFilterBuilder) Every(item Filter) Filter {
+	return b.build(FilterRollup{Every: &item})
+}
+func (b RollupFilterBuilder) None(item Filter) Filter {
+	return b.build(FilterRollup{None: &item})
+}
+func (b RollupFilterBuilder) Date() DateFilterBuilder {
+	return DateFilterBuilder{func(c FilterDate) Filter { return b.build(FilterRollup{Date: &c}) }}
+}
+func (b RollupFilterBuilder) Number() NumberFilterBuilder {
+	return NumberFilterBuilder{func(c FilterNumber) Filter { return b.build(FilterRollup{Number: &c}) }}
+}
+
+// OptionFilterBuilder はセレクトまたはステータスの条件を組み立てます
+type OptionFilterBuilder struct {
+	build func(FilterSelect) Filter
+}
+
+func (b OptionFilterBuilder) Equals(name string) Filter {
+	return b.build(FilterSelect{Equals: name})
+}
+func (b OptionFilterBuilder) DoesNotEqual(name string) Filter {
+	return b.build(FilterSelect{DoesNotEqual: name})
+}
+func (b OptionFilterBuilder) IsEmpty() Filter {
+	return b.build(FilterSelect{IsEmpty: true})
+}
+func (b OptionFilterBuilder) IsNotEmpty() Filter {
+	return b.build(FilterSelect{IsNotEmpty: true})
+}
+
+// maxFilterNesting は複合フィルターを入れ子にできる深さです
+const maxFilterNesting = 2
+
+// ValidateFilter は、filter が db のスキーマに対して有効かをリクエストの送信前に検証します
+// プロパティの名前またはIDが存在すること、条件の種類がプロパティの種類と一致すること、
+// 各フィルターにちょうど1つの条件が設定されていること、複合フィルターの入れ子が深すぎないことを検証します
+func ValidateFilter(filter Filter, db *Database) error {
+	return filter.validate(db.Properties, "filter", 0)
+}
+
+func (f Filter) validate(schema map[string]Property, path string, depth int) error {
+	switch {
+	case f.And != nil || f.Or != nil:
+		if f.And != nil && f.Or != nil || f.Property != "" || f.Timestamp != "" {
+			return fmt.Errorf("%s: compound filter must not have other conditions", path)
+		}
+		if depth >= maxFilterNesting {
+			return fmt.Errorf("%s: compound filters can be nested only %d levels deep", path, maxFilterNesting)
+		}
+		filters, key := f.And, "and"
+		if f.Or != nil {
+			filters, key = f.Or, "or"
+		}
+		for i, sub := range filters {
+			if err := sub.validate(schema, path+"."+key+"["+strconv.Itoa(i)+"]", depth+1); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case f.Timestamp != "":
+		if f.Property != "" {
+			return fmt.Errorf("%s: timestamp filter must not have property", path)
+		}
+		if _, err := f.condition(); err == nil {
+			return fmt.Errorf("%s: timestamp filter must not have property condition", path)
+		}
+		switch {
+		case f.Timestamp == "created_time" && f.CreatedTime != nil && f.LastEditedTime == nil:
+			return f.CreatedTime.validate(path + ".created_time")
+		case f.Timestamp == "last_edited_time" && f.LastEditedTime != nil && f.CreatedTime == nil:
+			return f.LastEditedTime.validate(path + ".last_edited_time")
+		}
+		return fmt.Errorf("%s: timestamp %q requires exactly the matching condition", path, f.Timestamp)
+
+	case f.Property != "":
+		prop, ok := findSchemaProperty(schema, f.Property)
+		if !ok {
+			return fmt.Errorf("%s: property %q does not exist in the database", path, f.Property)
+		}
+		return f.validateCondition(prop.Type, path)
+
+	default:
+		return fmt.Errorf("%s: filter has neither property, timestamp nor compound condition", path)
+	}
+}
+
+// validateCondition は、f の条件が種類 typ のプロパティに適用できるかを検証します
+func (f Filter) validateCondition(typ string, path string) error {
+	if err := f.checkPropertyType(typ); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	switch {
+	case f.Date != nil:
+		return f.Date.validate(path + ".date")
+	case f.Formula != nil:
+		c := f.Formula
+		switch countTrue(c.Checkbox != nil, c.Date != nil, c.Number != nil, c.String != nil) {
+		case 0:
+			return fmt.Errorf("%s.formula: condition is empty", path)
+		case 1:
+			if c.Date != nil {
+				return c.Date.validate(path + ".formula.date")
+			}
+			return nil
+		}
+		return fmt.Errorf("%s.formula: multiple conditions are specified", path)
+	case f.Rollup != nil:
+		c := f.Rollup
+		switch countTrue(c.Any != nil, c.Every != nil, c.None != nil, c.Date != nil, c.Number != nil) {
+		case 0:
+			return fmt.Errorf("%s.rollup: condition is empty", path)
+		case 1:
+		default:
+			return fmt.Errorf("%s.rollup: multiple conditions are specified", path)
+		}
+		for key, item := range map[string]*Filter{"any": c.Any, "every": c.Every, "none": c.None} {
+			if item != nil {
+				// 要素の種類はスキーマからは分からないため、条件の形だけを検証します
+				if _, err := item.condition(); err != nil {
+					return fmt.Errorf("%s.rollup.%s: %w", path, key, err)
+				}
+			}
+		}
+		if c.Date != nil {
+			return c.Date.validate(path + ".rollup.date")
+		}
+	}
+	return nil
+}
+
+// validate は日付の条件に指定された日付を解釈できるかを検証します
+func (c *FilterDate) validate(path string) error {
+	for _, date := range []string{c.After, c.Before, c.Equals, c.OnOrAfter, c.OnOrBefore} {
+		if date != "" {
+			if _, _, err := parseDate(date, nil); err != nil {
+				return fmt.Errorf("%s: %w", path, err)
+			}
+		}
+	}
+	return nil
+}
+
+func countTrue(values ...bool) int {
+	n := 0
+	for _, v := range values {
+		if v {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,58 @@
+package notion
+
+import (
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterBuilder(t *testing.T) {
+	assert.Equal(t, Filter{And: []Filter{
+		{Property: "Done", Checkbox: &FilterCheckbox{Equals: lo.ToPtr(true)}},
+		{Timestamp: "created_time", CreatedTime: &FilterDate{PastWeek: &struct{}{}}},
+		{Property: "Calc", Formula: &FilterFormula{String: &FilterRichText{Contains: "a"}}},
+		{Property: "Sum", Rollup: &FilterRollup{Any: &Filter{Number: &FilterNumber{GreaterThan: lo.ToPtr(1.0)}}}},
+		{Property: "Stage", Status: &FilterStatus{Equals: "Done"}},
+	}}, F.And(
+		F.Property("Done").Checkbox().Equals(true),
+		F.CreatedTime().PastWeek(),
+		F.Property("Calc").Formula().String().Contains("a"),
+		F.Property("Sum").Rollup().Any(F.Item().Number().GreaterThan(1)),
+		F.Property("Stage").Status().Equals("Done"),
+	))
+}
+
+func TestValidateFilter(t *testing.T) {
+	db := &Database{Properties: map[string]Property{
+		"Name":  {Type: "title", Id: "title"},
+		"Done":  {Type: "checkbox", Id: "a%3Db"},
+		"Calc":  {Type: "formula", Id: "c"},
+		"Stage": {Type: "status", Id: "d"},
+	}}
+
+	valid := []Filter{
+		F.Property("Done").Checkbox().Equals(true),
+		F.Property("a%3Db").Checkbox().DoesNotEqual(true),
+		F.Property("Name").Text().StartsWith("x"),
+		F.Or(F.LastEditedTime().OnOrAfter("2024-01-01"), F.And(F.Property("Calc").Formula().Number().IsEmpty())),
+	}
+	for _, f := range valid {
+		assert.NoError(t, ValidateFilter(f, db))
+	}
+
+	invalid := map[string]Filter{
+		"missing property":   F.Property("Missing").Checkbox().Equals(true),
+		"type mismatch":      F.Property("Stage").Select().Equals("Done"),
+		"empty condition":    {Property: "Done"},
+		"multiple":           {Property: "Done", Checkbox: &FilterCheckbox{}, Number: &FilterNumber{}},
+		"timestamp property": {Timestamp: "created_time", Property: "Name", CreatedTime: &FilterDate{PastWeek: &struct{}{}}},
+		"timestamp mismatch": {Timestamp: "created_time", LastEditedTime: &FilterDate{PastWeek: &struct{}{}}},
+		"invalid date":       F.CreatedTime().Before("yesterday"),
+		"empty formula":      {Property: "Calc", Formula: &FilterFormula{}},
+		"too deep":           F.And(F.Or(F.And(F.Property("Done").Checkbox().Equals(true)))),
+	}
+	for name, f := range invalid {
+		assert.Error(t, ValidateFilter(f, db), name)
+	}
+}
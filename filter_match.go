@@ -0,0 +1,496 @@
+package notion
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// now は相対的な日付の条件（past_week など）の基準となる現在時刻を返します（テストで差し替えられます）
+var now = time.Now
+
+// Match は page が f の条件を満たすかをローカルで評価します
+// schema は page が属するデータベースのプロパティで、条件とプロパティの種類の対応を検証するために使用します
+//
+// テキストの contains・does_not_contain・starts_with・ends_with は大文字と小文字を区別しません。
+// 日付の範囲は開始日で評価され、this_week は月曜日から始まる週として扱われます。
+func (f Filter) Match(page *Page, schema map[string]Property) (bool, error) {
+	switch {
+	case f.And != nil:
+		for _, sub := range f.And {
+			if ok, err := sub.Match(page, schema); err != nil || !ok {
+				return false, err
+			}
+		}
+		return true, nil
+
+	case f.Or != nil:
+		for _, sub := range f.Or {
+			if ok, err := sub.Match(page, schema); err != nil || ok {
+				return ok, err
+			}
+		}
+		return false, nil
+
+	case f.Timestamp != "":
+		switch f.Timestamp {
+		case "created_time":
+			return matchDateCondition(f.CreatedTime, page.CreatedTime, nil)
+		case "last_edited_time":
+			return matchDateCondition(f.LastEditedTime, page.LastEditedTime, nil)
+		default:
+			return false, fmt.Errorf("unknown timestamp: %q", f.Timestamp)
+		}
+
+	case f.Property != "":
+		prop, ok := findSchemaProperty(schema, f.Property)
+		if !ok {
+			return false, fmt.Errorf("property %q does not exist in the schema", f.Property)
+		}
+		value := page.Properties.Get(prop.Id)
+		if value == nil {
+			if v, ok := page.Properties[prop.Name]; ok {
+				value = &v
+			} else {
+				value = &PropertyValue{}
+			}
+		}
+		return f.matchValue(prop.Type, value)
+
+	default:
+		return false, fmt.Errorf("filter has neither property, timestamp nor compound condition")
+	}
+}
+
+// findSchemaProperty は名前またはIDが nameOrId に一致するプロパティを探します
+func findSchemaProperty(schema map[string]Property, nameOrId string) (Property, bool) {
+	if prop, ok := schema[nameOrId]; ok {
+		if prop.Name == "" {
+			prop.Name = nameOrId
+		}
+		return prop, true
+	}
+	for name, prop := range schema {
+		if prop.Id == nameOrId {
+			if prop.Name == "" {
+				prop.Name = name
+			}
+			return prop, true
+		}
+	}
+	return Property{}, false
+}
+
+// filterPropertyTypes は、フィルターの条件ごとに適用できるプロパティの種類です
+var filterPropertyTypes = map[string][]string{
+	"checkbox":     {"checkbox"},
+	"date":         {"date", "created_time", "last_edited_time"},
+	"files":        {"files"},
+	"formula":      {"formula"},
+	"multi_select": {"multi_select"},
+	"number":       {"number", "unique_id"},
+	"people":       {"people", "created_by", "last_edited_by"},
+	"relation":     {"relation"},
+	"rich_text":    {"title", "rich_text", "url", "email", "phone_number"},
+	"rollup":       {"rollup"},
+	"select":       {"select"},
+	"status":       {"status"},
+}
+
+// condition は f に設定されたプロパティの条件の種類を返します
+func (f Filter) condition() (string, error) {
+	set := []string{}
+	for name, ok := range map[string]bool{
+		"checkbox":     f.Checkbox != nil,
+		"date":         f.Date != nil,
+		"files":        f.Files != nil,
+		"formula":      f.Formula != nil,
+		"multi_select": f.MultiSelect != nil,
+		"number":       f.Number != nil,
+		"people":       f.People != nil,
+		"relation":     f.Relation != nil,
+		"rich_text":    f.RichText != nil,
+		"rollup":       f.Rollup != nil,
+		"select":       f.Select != nil,
+		"status":       f.Status != nil,
+	} {
+		if ok {
+			set = append(set, name)
+		}
+	}
+	switch len(set) {
+	case 0:
+		return "", fmt.Errorf("filter for property %q has no condition", f.Property)
+	case 1:
+		return set[0], nil
+	default:
+		slices.Sort(set)
+		return "", fmt.Errorf("filter for property %q has multiple conditions: %s", f.Property, strings.Join(set, ", "))
+	}
+}
+
+// checkPropertyType は、f の条件が種類 typ のプロパティに適用できるかを検証します
+func (f Filter) checkPropertyType(typ string) error {
+	condition, err := f.condition()
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(filterPropertyTypes[condition], typ) {
+		return fmt.Errorf("%s filter cannot be applied to %s property %q", condition, typ, f.Property)
+	}
+	return nil
+}
+
+// matchValue は、種類が typ のプロパティ値 value が f の条件を満たすかを返します
+func (f Filter) matchValue(typ string, value *PropertyValue) (bool, error) {
+	if err := f.checkPropertyType(typ); err != nil {
+		return false, err
+	}
+
+	switch {
+	case f.Checkbox != nil:
+		return matchCheckboxCondition(f.Checkbox, value.Checkbox)
+
+	case f.Date != nil:
+		switch typ {
+		case "created_time":
+			return matchDateCondition(f.Date, value.CreatedTime, nil)
+		case "last_edited_time":
+			return matchDateCondition(f.Date, value.LastEditedTime, nil)
+		}
+		if value.Date == nil {
+			return matchDateCondition(f.Date, "", nil)
+		}
+		return matchDateCondition(f.Date, value.Date.Start, value.Date.TimeZone)
+
+	case f.Files != nil:
+		return matchEmptiness(f.Files.IsEmpty, f.Files.IsNotEmpty, len(value.Files) == 0)
+
+	case f.Formula != nil:
+		return matchFormulaCondition(f.Formula, value.Formula)
+
+	case f.MultiSelect != nil:
+		names := make([]string, len(value.MultiSelect))
+		for i, o := range value.MultiSelect {
+			names[i] = o.Name
+		}
+		return matchMultiSelectCondition(f.MultiSelect, names)
+
+	case f.Number != nil:
+		if typ == "unique_id" {
+			if value.UniqueId == nil {
+				return matchNumberCondition(f.Number, nil)
+			}
+			number := float64(value.UniqueId.Number)
+			return matchNumberCondition(f.Number, &number)
+		}
+		return matchNumberCondition(f.Number, value.Number)
+
+	case f.People != nil:
+		users := value.People
+		switch typ {
+		case "created_by":
+			users = []User{value.CreatedBy}
+		case "last_edited_by":
+			users = []User{value.LastEditedBy}
+		}
+		ids := make([]uuid.UUID, len(users))
+		for i, u := range users {
+			ids[i] = u.Id
+		}
+		return matchIdsCondition(f.People.Contains, f.People.DoesNotContain, f.People.IsEmpty, f.People.IsNotEmpty, ids)
+
+	case f.Relation != nil:
+		ids := make([]uuid.UUID, len(value.Relation))
+		for i, r := range value.Relation {
+			ids[i] = r.Id
+		}
+		return matchIdsCondition(f.Relation.Contains, f.Relation.DoesNotContain, f.Relation.IsEmpty, f.Relation.IsNotEmpty, ids)
+
+	case f.RichText != nil:
+		switch typ {
+		case "title":
+			return matchTextCondition(f.RichText, value.Title.String())
+		case "url":
+			return matchTextCondition(f.RichText, deref(value.Url))
+		case "email":
+			return matchTextCondition(f.RichText, deref(value.Email))
+		case "phone_number":
+			return matchTextCondition(f.RichText, deref(value.PhoneNumber))
+		}
+		return matchTextCondition(f.RichText, value.RichText.String())
+
+	case f.Rollup != nil:
+		return matchRollupCondition(f.Rollup, value.Rollup)
+
+	case f.Select != nil:
+		return matchOptionCondition(f.Select.Equals, f.Select.DoesNotEqual, f.Select.IsEmpty, f.Select.IsNotEmpty, value.Select)
+
+	default:
+		return matchOptionCondition(f.Status.Equals, f.Status.DoesNotEqual, f.Status.IsEmpty, f.Status.IsNotEmpty, value.Status)
+	}
+}
+
+// conditions は、設定された条件の評価結果を集め、全てを満たすかを返します
+type conditions struct {
+	count int
+	ok    bool
+}
+
+func newConditions() *conditions {
+	return &conditions{ok: true}
+}
+
+func (c *conditions) add(set bool, result func() bool) {
+	if set {
+		c.count++
+		c.ok = c.ok && result()
+	}
+}
+
+func (c *conditions) result() (bool, error) {
+	if c.count == 0 {
+		return false, fmt.Errorf("filter condition is empty")
+	}
+	return c.ok, nil
+}
+
+func matchEmptiness(isEmpty bool, isNotEmpty bool, empty bool) (bool, error) {
+	c := newConditions()
+	c.add(isEmpty, func() bool { return empty })
+	c.add(isNotEmpty, func() bool { return !empty })
+	return c.result()
+}
+
+func matchCheckboxCondition(cond *FilterCheckbox, value bool) (bool, error) {
+	c := newConditions()
+	c.add(cond.Equals != nil, func() bool { return value == *cond.Equals })
+	c.add(cond.DoesNotEqual != nil, func() bool { return value != *cond.DoesNotEqual })
+	return c.result()
+}
+
+func matchTextCondition(cond *FilterRichText, value string) (bool, error) {
+	lower := strings.ToLower(value)
+	c := newConditions()
+	c.add(cond.Equals != "", func() bool { return value == cond.Equals })
+	c.add(cond.DoesNotEqual != "", func() bool { return value != cond.DoesNotEqual })
+	c.add(cond.Contains != "", func() bool { return strings.Contains(lower, strings.ToLower(cond.Contains)) })
+	c.add(cond.DoesNotContain != "", func() bool { return !strings.Contains(lower, strings.ToLower(cond.DoesNotContain)) })
+	c.add(cond.StartsWith != "", func() bool { return strings.HasPrefix(lower, strings.ToLower(cond.StartsWith)) })
+	c.add(cond.EndsWith != "", func() bool { return strings.HasSuffix(lower, strings.ToLower(cond.EndsWith)) })
+	c.add(cond.IsEmpty, func() bool { return value == "" })
+	c.add(cond.IsNotEmpty, func() bool { return value != "" })
+	return c.result()
+}
+
+func matchNumberCondition(cond *FilterNumber, value *float64) (bool, error) {
+	compare := func(want *float64, op func(v, w float64) bool) func() bool {
+		return func() bool { return value != nil && op(*value, *want) }
+	}
+	c := newConditions()
+	c.add(cond.Equals != nil, compare(cond.Equals, func(v, w float64) bool { return v == w }))
+	c.add(cond.DoesNotEqual != nil, func() bool { return value == nil || *value != *cond.DoesNotEqual })
+	c.add(cond.GreaterThan != nil, compare(cond.GreaterThan, func(v, w float64) bool { return v > w }))
+	c.add(cond.GreaterThanOrEqualTo != nil, compare(cond.GreaterThanOrEqualTo, func(v, w float64) bool { return v >= w }))
+	c.add(cond.LessThan != nil, compare(cond.LessThan, func(v, w float64) bool { return v < w }))
+	c.add(cond.LessThanOrEqualTo != nil, compare(cond.LessThanOrEqualTo, func(v, w float64) bool { return v <= w }))
+	c.add(cond.IsEmpty, func() bool { return value == nil })
+	c.add(cond.IsNotEmpty, func() bool { return value != nil })
+	return c.result()
+}
+
+func matchOptionCondition(equals string, doesNotEqual string, isEmpty bool, isNotEmpty bool, value *Option) (bool, error) {
+	name := ""
+	if value != nil {
+		name = value.Name
+	}
+	c := newConditions()
+	c.add(equals != "", func() bool { return name == equals })
+	c.add(doesNotEqual != "", func() bool { return name != doesNotEqual })
+	c.add(isEmpty, func() bool { return name == "" })
+	c.add(isNotEmpty, func() bool { return name != "" })
+	return c.result()
+}
+
+func matchMultiSelectCondition(cond *FilterMultiSelect, names []string) (bool, error) {
+	c := newConditions()
+	c.add(cond.Contains != "", func() bool { return slices.Contains(names, cond.Contains) })
+	c.add(cond.DoesNotContain != "", func() bool { return !slices.Contains(names, cond.DoesNotContain) })
+	c.add(cond.IsEmpty, func() bool { return len(names) == 0 })
+	c.add(cond.IsNotEmpty, func() bool { return len(names) != 0 })
+	return c.result()
+}
+
+func matchIdsCondition(contains *uuid.UUID, doesNotContain *uuid.UUID, isEmpty bool, isNotEmpty bool, ids []uuid.UUID) (bool, error) {
+	c := newConditions()
+	c.add(contains != nil, func() bool { return slices.Contains(ids, *contains) })
+	c.add(doesNotContain != nil, func() bool { return !slices.Contains(ids, *doesNotContain) })
+	c.add(isEmpty, func() bool { return len(ids) == 0 })
+	c.add(isNotEmpty, func() bool { return len(ids) != 0 })
+	return c.result()
+}
+
+// matchDateCondition は日付の値 value（空文字列は値なし）が cond を満たすかを返します
+func matchDateCondition(cond *FilterDate, value string, timeZone *string) (bool, error) {
+	if cond == nil {
+		return false, fmt.Errorf("date filter condition is not specified")
+	}
+
+	var v time.Time
+	var vDateOnly bool
+	if value != "" {
+		t, dateOnly, err := parseDate(value, timeZone)
+		if err != nil {
+			return false, err
+		}
+		v, vDateOnly = t, dateOnly
+	}
+
+	var parseErr error
+	compare := func(want ISO8601String, op func(cmp int) bool) func() bool {
+		return func() bool {
+			w, wDateOnly, err := parseDate(want, nil)
+			if err != nil {
+				parseErr = err
+				return false
+			}
+			if value == "" {
+				return false
+			}
+			if vDateOnly || wDateOnly {
+				return op(day(v).Compare(day(w)))
+			}
+			return op(v.Compare(w))
+		}
+	}
+	within := func(from, to time.Time) func() bool {
+		return func() bool {
+			return value != "" && !day(v).Before(from) && !day(v).After(to)
+		}
+	}
+
+	today := day(now())
+	monday := today.AddDate(0, 0, -(int(today.Weekday())+6)%7)
+
+	c := newConditions()
+	c.add(cond.Equals != "", compare(cond.Equals, func(cmp int) bool { return cmp == 0 }))
+	c.add(cond.Before != "", compare(cond.Before, func(cmp int) bool { return cmp < 0 }))
+	c.add(cond.After != "", compare(cond.After, func(cmp int) bool { return cmp > 0 }))
+	c.add(cond.OnOrBefore != "", compare(cond.OnOrBefore, func(cmp int) bool { return cmp <= 0 }))
+	c.add(cond.OnOrAfter != "", compare(cond.OnOrAfter, func(cmp int) bool { return cmp >= 0 }))
+	c.add(cond.IsEmpty, func() bool { return value == "" })
+	c.add(cond.IsNotEmpty, func() bool { return value != "" })
+	c.add(cond.PastWeek != nil, within(today.AddDate(0, 0, -7), today))
+	c.add(cond.PastMonth != nil, within(today.AddDate(0, -1, 0), today))
+	c.add(cond.PastYear != nil, within(today.AddDate(-1, 0, 0), today))
+	c.add(cond.NextWeek != nil, within(today, today.AddDate(0, 0, 7)))
+	c.add(cond.NextMonth != nil, within(today, today.AddDate(0, 1, 0)))
+	c.add(cond.NextYear != nil, within(today, today.AddDate(1, 0, 0)))
+	c.add(cond.ThisWeek != nil, within(monday, monday.AddDate(0, 0, 6)))
+
+	ok, err := c.result()
+	if parseErr != nil {
+		return false, parseErr
+	}
+	return ok, err
+}
+
+// parseDate は日付または日時を解釈し、日付のみかどうかと共に返します
+// オフセットを持たない日時は timeZone（nil の場合はUTC）の時刻として解釈されます
+func parseDate(value string, timeZone *string) (time.Time, bool, error) {
+	loc := time.UTC
+	if timeZone != nil {
+		if l, err := time.LoadLocation(*timeZone); err == nil {
+			loc = l
+		}
+	}
+	if t, err := time.ParseInLocation(time.DateOnly, value, time.UTC); err == nil {
+		return t, true, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, false, nil
+	}
+	for _, layout := range []string{"2006-01-02T15:04:05", "2006-01-02T15:04"} {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return t, false, nil
+		}
+	}
+	return time.Time{}, false, fmt.Errorf("invalid date: %q", value)
+}
+
+// day は t の日付（UTC）を返します
+func day(t time.Time) time.Time {
+	y, m, d := t.UTC().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func matchFormulaCondition(cond *FilterFormula, value *Formula) (bool, error) {
+	if value == nil {
+		value = &Formula{}
+	}
+	switch {
+	case cond.String != nil:
+		return matchTextCondition(cond.String, deref(value.String))
+	case cond.Checkbox != nil:
+		return matchCheckboxCondition(cond.Checkbox, value.Boolean)
+	case cond.Number != nil:
+		if value.Type != "number" {
+			return matchNumberCondition(cond.Number, nil)
+		}
+		return matchNumberCondition(cond.Number, &value.Number)
+	case cond.Date != nil:
+		return matchDateCondition(cond.Date, value.Date.Start, value.Date.TimeZone)
+	default:
+		return false, fmt.Errorf("formula filter condition is empty")
+	}
+}
+
+func matchRollupCondition(cond *FilterRollup, value *Rollup) (bool, error) {
+	if value == nil {
+		value = &Rollup{}
+	}
+
+	matchEach := func(f *Filter) ([]bool, error) {
+		results := []bool{}
+		for _, item := range value.Array {
+			ok, err := f.matchValue(item.Type, &item)
+			if err != nil {
+				return nil, err
+			}
+			results = append(results, ok)
+		}
+		return results, nil
+	}
+
+	switch {
+	case cond.Any != nil:
+		results, err := matchEach(cond.Any)
+		return slices.Contains(results, true), err
+	case cond.Every != nil:
+		results, err := matchEach(cond.Every)
+		return !slices.Contains(results, false), err
+	case cond.None != nil:
+		results, err := matchEach(cond.None)
+		return !slices.Contains(results, true), err
+	case cond.Number != nil:
+		return matchNumberCondition(cond.Number, value.Number)
+	case cond.Date != nil:
+		if value.Date == nil {
+			return matchDateCondition(cond.Date, "", nil)
+		}
+		return matchDateCondition(cond.Date, value.Date.Start, value.Date.TimeZone)
+	default:
+		return false, fmt.Errorf("rollup filter condition is empty")
+	}
+}
+
+func deref[T any](p *T) T {
+	var zero T
+	if p == nil {
+		return zero
+	}
+	return *p
+}
@@ -0,0 +1,128 @@
+package notion
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterMatch(t *testing.T) {
+	now = func() time.Time { return time.Date(2024, 5, 15, 12, 0, 0, 0, time.UTC) } // 水曜日
+	defer func() { now = time.Now }()
+
+	userId := uuid.MustParse("9a4d3c2e-3b1f-4f0e-8d6f-1a2b3c4d5e6f")
+	relatedId := uuid.MustParse("1f0e2d3c-4b5a-4968-8776-a5b4c3d2e1f0")
+
+	schema := map[string]Property{
+		"Name":   {Type: "title", Id: "title"},
+		"Done":   {Type: "checkbox", Id: "a"},
+		"Due":    {Type: "date", Id: "b"},
+		"Points": {Type: "number", Id: "c"},
+		"Tags":   {Type: "multi_select", Id: "d", MultiSelect: &PropertyMultiSelect{Options: []OptionDescription{{Name: "x"}, {Name: "y"}}}},
+		"Stage":  {Type: "status", Id: "e"},
+		"Owner":  {Type: "people", Id: "f"},
+		"Parent": {Type: "relation", Id: "g"},
+		"Files":  {Type: "files", Id: "h"},
+		"Calc":   {Type: "formula", Id: "i"},
+		"Sum":    {Type: "rollup", Id: "j"},
+		"Kind":   {Type: "select", Id: "k", Select: &PropertySelect{Options: []OptionDescription{{Name: "B"}, {Name: "A"}}}},
+	}
+	page := &Page{
+		CreatedTime: "2024-05-13T09:00:00.000Z",
+		Properties: PropertyValueMap{
+			"Name":   {Type: "title", Id: "title", Title: NewRichTextArray("Write Report")},
+			"Done":   {Type: "checkbox", Id: "a", Checkbox: true},
+			"Due":    {Type: "date", Id: "b", Date: &PropertyValueDate{Start: "2024-05-20"}},
+			"Points": {Type: "number", Id: "c", Number: lo.ToPtr(3.0)},
+			"Tags":   {Type: "multi_select", Id: "d", MultiSelect: []Option{{Name: "x"}}},
+			"Stage":  {Type: "status", Id: "e", Status: &Option{Name: "Doing"}},
+			"Owner":  {Type: "people", Id: "f", People: []User{{Id: userId}}},
+			"Parent": {Type: "relation", Id: "g", Relation: []PageReference{{Id: relatedId}}},
+			"Files":  {Type: "files", Id: "h", Files: []File{}},
+			"Calc":   {Type: "formula", Id: "i", Formula: &Formula{Type: "number", Number: 42}},
+			"Sum": {Type: "rollup", Id: "j", Rollup: &Rollup{Type: "array", Array: []PropertyValue{
+				{Type: "number", Number: lo.ToPtr(1.0)},
+				{Type: "number", Number: lo.ToPtr(5.0)},
+			}}},
+			"Kind": {Type: "select", Id: "k", Select: &Option{Name: "A"}},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		filter Filter
+		want   bool
+	}{
+		{"checkbox", Filter{Property: "Done", Checkbox: &FilterCheckbox{Equals: lo.ToPtr(true)}}, true},
+		{"checkbox by id", Filter{Property: "a", Checkbox: &FilterCheckbox{DoesNotEqual: lo.ToPtr(true)}}, false},
+		{"title contains", Filter{Property: "Name", RichText: &FilterRichText{Contains: "report"}}, true},
+		{"title starts_with", Filter{Property: "Name", RichText: &FilterRichText{StartsWith: "Read"}}, false},
+		{"date on_or_after", Filter{Property: "Due", Date: &FilterDate{OnOrAfter: "2024-05-20T10:00:00Z"}}, true},
+		{"date before", Filter{Property: "Due", Date: &FilterDate{Before: "2024-05-20"}}, false},
+		{"date next_week", Filter{Property: "Due", Date: &FilterDate{NextWeek: &struct{}{}}}, true},
+		{"date past_week", Filter{Property: "Due", Date: &FilterDate{PastWeek: &struct{}{}}}, false},
+		{"date this_week", Filter{Property: "Due", Date: &FilterDate{ThisWeek: &struct{}{}}}, false},
+		{"number", Filter{Property: "Points", Number: &FilterNumber{GreaterThan: lo.ToPtr(2.0), LessThanOrEqualTo: lo.ToPtr(3.0)}}, true},
+		{"multi_select", Filter{Property: "Tags", MultiSelect: &FilterMultiSelect{DoesNotContain: "x"}}, false},
+		{"select", Filter{Property: "Kind", Select: &FilterSelect{Equals: "A"}}, true},
+		{"status", Filter{Property: "Stage", Status: &FilterStatus{IsEmpty: true}}, false},
+		{"people", Filter{Property: "Owner", People: &FilterPeople{Contains: &userId}}, true},
+		{"relation", Filter{Property: "Parent", Relation: &FilterRelation{DoesNotContain: &relatedId}}, false},
+		{"files", Filter{Property: "Files", Files: &FilterFiles{IsEmpty: true}}, true},
+		{"formula", Filter{Property: "Calc", Formula: &FilterFormula{Number: &FilterNumber{Equals: lo.ToPtr(42.0)}}}, true},
+		{"rollup any", Filter{Property: "Sum", Rollup: &FilterRollup{Any: &Filter{Number: &FilterNumber{GreaterThan: lo.ToPtr(4.0)}}}}, true},
+		{"rollup every", Filter{Property: "Sum", Rollup: &FilterRollup{Every: &Filter{Number: &FilterNumber{GreaterThan: lo.ToPtr(4.0)}}}}, false},
+		{"timestamp this_week", Filter{Timestamp: "created_time", CreatedTime: &FilterDate{ThisWeek: &struct{}{}}}, true},
+		{"and", Filter{And: []Filter{
+			{Property: "Done", Checkbox: &FilterCheckbox{Equals: lo.ToPtr(true)}},
+			{Or: []Filter{
+				{Property: "Points", Number: &FilterNumber{Equals: lo.ToPtr(1.0)}},
+				{Property: "Tags", MultiSelect: &FilterMultiSelect{Contains: "x"}},
+			}},
+		}}, true},
+		{"or", Filter{Or: []Filter{
+			{Property: "Done", Checkbox: &FilterCheckbox{Equals: lo.ToPtr(false)}},
+			{Property: "Points", Number: &FilterNumber{IsEmpty: true}},
+		}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.filter.Match(page, schema)
+			if assert.NoError(t, err) {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := Filter{Property: "Missing", Checkbox: &FilterCheckbox{Equals: lo.ToPtr(true)}}.Match(page, schema)
+		assert.Error(t, err)
+		_, err = Filter{Property: "Points", Checkbox: &FilterCheckbox{Equals: lo.ToPtr(true)}}.Match(page, schema)
+		assert.Error(t, err)
+		_, err = Filter{Property: "Points", Number: &FilterNumber{}}.Match(page, schema)
+		assert.Error(t, err)
+	})
+
+	t.Run("SortPages", func(t *testing.T) {
+		pages := []Page{
+			{Id: uuid.New(), Properties: PropertyValueMap{"Kind": {Select: &Option{Name: "A"}}, "Points": {Number: lo.ToPtr(1.0)}}},
+			{Id: uuid.New(), Properties: PropertyValueMap{"Kind": {}, "Points": {Number: lo.ToPtr(2.0)}}},
+			{Id: uuid.New(), Properties: PropertyValueMap{"Kind": {Select: &Option{Name: "B"}}, "Points": {Number: lo.ToPtr(3.0)}}},
+			{Id: uuid.New(), Properties: PropertyValueMap{"Kind": {Select: &Option{Name: "A"}}, "Points": {Number: lo.ToPtr(4.0)}}},
+		}
+		points := func() []float64 {
+			return lo.Map(pages, func(p Page, _ int) float64 { return *p.Properties["Points"].Number })
+		}
+
+		assert.NoError(t, SortPages(pages, []Sort{{Property: "Kind", Direction: "ascending"}, {Property: "Points", Direction: "descending"}}, schema))
+		assert.Equal(t, []float64{3, 4, 1, 2}, points())
+
+		assert.NoError(t, SortPages(pages, []Sort{{Property: "Kind", Direction: "descending"}}, schema))
+		assert.Equal(t, []float64{4, 1, 3, 2}, points())
+
+		assert.Error(t, SortPages(pages, []Sort{{Property: "Kind", Direction: "up"}}, schema))
+	})
+}
@@ -0,0 +1,983 @@
+package notion
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/google/uuid"
+)
+
+/*
+ParseQuery はテキストで書かれたクエリを解釈し、データベースクエリのフィルターと並べ替えを返します
+クエリに条件が無い場合、フィルターは nil になります
+
+	Status = "Done" and (Tags contains "A" or Tags contains "B") order by Due desc
+
+条件は「プロパティ 演算子 値」の形で書き、and・or・括弧で組み合わせます（and が or より優先されます）。
+プロパティは名前またはIDで指定し、空白や記号を含む場合は "..." で囲みます。
+@created_time と @last_edited_time はページのタイムスタンプを表します。
+条件の種類は db.Properties におけるプロパティの種類から決まります。
+
+演算子は以下の通りです
+
+	= != > >= < <=
+	contains, not contains, starts with, ends with
+	is empty, is not empty
+	in past week, in past month, in past year, in next week, in next month, in next year, in this week
+
+日付の < と > はそれぞれ before と after を、<= と >= は on_or_before と on_or_after を表します。
+formula と rollup は結果の種類をスキーマから判断できないため、値から推測するか、
+Calc:number > 1、Sum:any:select = "A" のように「:」に続けて明示します。
+formula の種類は string・number・checkbox・date、rollup の種類は any・every・none・number・date で、
+any・every・none には更に要素の条件の種類（select など）を続けられます。
+
+order by に続けて、並べ替えるプロパティと方向（asc または desc、省略時は asc）をカンマ区切りで指定します。
+*/
+func ParseQuery(query string, db *Database) (*Filter, []Sort, error) {
+	tokens, err := tokenizeQuery(query)
+	if err != nil {
+		return nil, nil, err
+	}
+	p := &queryParser{tokens: tokens, schema: db.Properties}
+
+	var filter *Filter
+	if !p.peekKeyword("order") && !p.done() {
+		f, err := p.parseOr()
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := f.validate(db.Properties, "filter", 0); err != nil {
+			return nil, nil, err
+		}
+		filter = &f
+	}
+
+	var sorts []Sort
+	if p.peekKeyword("order") {
+		if sorts, err = p.parseSorts(); err != nil {
+			return nil, nil, err
+		}
+	}
+	if !p.done() {
+		return nil, nil, p.errorf("unexpected %s", p.peek())
+	}
+	return filter, sorts, nil
+}
+
+// FormatQuery は filter と sorts を ParseQuery で解釈できるテキストに変換します
+// filter が nil の場合は並べ替えのみを出力します
+func FormatQuery(filter *Filter, sorts []Sort) string {
+	parts := []string{}
+	if filter != nil {
+		parts = append(parts, formatFilter(*filter))
+	}
+	if len(sorts) != 0 {
+		keys := make([]string, len(sorts))
+		for i, s := range sorts {
+			key := "@" + s.Timestamp
+			if s.Timestamp == "" {
+				key = formatQueryName(s.Property)
+			}
+			if s.Direction == "descending" {
+				key += " desc"
+			}
+			keys[i] = key
+		}
+		parts = append(parts, "order by "+strings.Join(keys, ", "))
+	}
+	return strings.Join(parts, " ")
+}
+
+// String はフィルターをクエリのテキストとして返します
+func (f Filter) String() string {
+	return formatFilter(f)
+}
+
+const (
+	tokenName   = "name"
+	tokenString = "string"
+	tokenNumber = "number"
+	tokenSymbol = "symbol"
+)
+
+type queryToken struct {
+	kind string
+	text string // string は解釈後の文字列
+	pos  int    // クエリにおける位置（文字数）
+}
+
+func (t queryToken) String() string {
+	if t.kind == "" {
+		return "end of query"
+	}
+	return strconv.Quote(t.text)
+}
+
+// queryKeywords は、引用符で囲まずにプロパティ名として使えない単語です
+var queryKeywords = []string{"and", "or", "order", "by", "asc", "desc", "contains", "not", "starts", "ends", "with", "is", "empty", "in", "past", "next", "this", "week", "month", "year", "true", "false"}
+
+func isQueryNameRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.Is(unicode.Mn, r)
+}
+
+func tokenizeQuery(query string) ([]queryToken, error) {
+	tokens := []queryToken{}
+	runes := []rune(query)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+
+		case r == '"':
+			j := i + 1
+			for ; j < len(runes) && runes[j] != '"'; j++ {
+				if runes[j] == '\\' {
+					j++
+				}
+			}
+			if j >= len(runes) {
+				return nil, fmt.Errorf("query:%d: unterminated string", i+1)
+			}
+			s, err := strconv.Unquote(string(runes[i : j+1]))
+			if err != nil {
+				return nil, fmt.Errorf("query:%d: invalid string: %w", i+1, err)
+			}
+			tokens = append(tokens, queryToken{kind: tokenString, text: s, pos: i})
+			i = j + 1
+
+		case r == '-' || r == '.' || unicode.IsDigit(r):
+			j := i + 1
+			for ; j < len(runes) && (isQueryNameRune(runes[j]) || runes[j] == '.' || (runes[j] == '-' || runes[j] == '+') && (runes[j-1] == 'e' || runes[j-1] == 'E')); j++ {
+			}
+			text := string(runes[i:j])
+			if _, err := strconv.ParseFloat(text, 64); err != nil {
+				if r == '-' || r == '.' {
+					return nil, fmt.Errorf("query:%d: invalid number %q", i+1, text)
+				}
+				tokens = append(tokens, queryToken{kind: tokenName, text: text, pos: i})
+			} else {
+				tokens = append(tokens, queryToken{kind: tokenNumber, text: text, pos: i})
+			}
+			i = j
+
+		case isQueryNameRune(r):
+			j := i + 1
+			for ; j < len(runes) && isQueryNameRune(runes[j]); j++ {
+			}
+			tokens = append(tokens, queryToken{kind: tokenName, text: string(runes[i:j]), pos: i})
+			i = j
+
+		case strings.ContainsRune("!<>", r) && i+1 < len(runes) && runes[i+1] == '=':
+			tokens = append(tokens, queryToken{kind: tokenSymbol, text: string(runes[i : i+2]), pos: i})
+			i += 2
+
+		case strings.ContainsRune("()=<>,:@", r):
+			tokens = append(tokens, queryToken{kind: tokenSymbol, text: string(r), pos: i})
+			i++
+
+		default:
+			return nil, fmt.Errorf("query:%d: unexpected character %q", i+1, r)
+		}
+	}
+	return tokens, nil
+}
+
+type queryParser struct {
+	tokens []queryToken
+	pos    int
+	schema map[string]Property
+}
+
+func (p *queryParser) done() bool {
+	return p.pos >= len(p.tokens)
+}
+
+func (p *queryParser) peek() queryToken {
+	if p.done() {
+		return queryToken{pos: -1}
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *queryParser) next() queryToken {
+	t := p.peek()
+	p.pos++
+	return t
+}
+
+func (p *queryParser) errorf(format string, args ...any) error {
+	pos := len(p.tokens)
+	if !p.done() {
+		pos = p.tokens[p.pos].pos + 1
+	} else if pos != 0 {
+		pos = p.tokens[pos-1].pos + 1
+	}
+	return fmt.Errorf("query:%d: %s", pos, fmt.Sprintf(format, args...))
+}
+
+func (p *queryParser) peekKeyword(keyword string) bool {
+	t := p.peek()
+	return t.kind == tokenName && strings.EqualFold(t.text, keyword)
+}
+
+func (p *queryParser) peekSymbol(symbol string) bool {
+	t := p.peek()
+	return t.kind == tokenSymbol && t.text == symbol
+}
+
+// acceptKeywords は、続くトークンが keywords に一致すれば読み進めて true を返します
+func (p *queryParser) acceptKeywords(keywords ...string) bool {
+	for i, keyword := range keywords {
+		if p.pos+i >= len(p.tokens) {
+			return false
+		}
+		t := p.tokens[p.pos+i]
+		if t.kind != tokenName || !strings.EqualFold(t.text, keyword) {
+			return false
+		}
+	}
+	p.pos += len(keywords)
+	return true
+}
+
+func (p *queryParser) parseOr() (Filter, error) {
+	first, err := p.parseAnd()
+	if err != nil {
+		return Filter{}, err
+	}
+	filters := []Filter{first}
+	for p.acceptKeywords("or") {
+		f, err := p.parseAnd()
+		if err != nil {
+			return Filter{}, err
+		}
+		filters = append(filters, f)
+	}
+	if len(filters) == 1 {
+		return first, nil
+	}
+	return Filter{Or: filters}, nil
+}
+
+func (p *queryParser) parseAnd() (Filter, error) {
+	first, err := p.parseFactor()
+	if err != nil {
+		return Filter{}, err
+	}
+	filters := []Filter{first}
+	for p.acceptKeywords("and") {
+		f, err := p.parseFactor()
+		if err != nil {
+			return Filter{}, err
+		}
+		filters = append(filters, f)
+	}
+	if len(filters) == 1 {
+		return first, nil
+	}
+	return Filter{And: filters}, nil
+}
+
+func (p *queryParser) parseFactor() (Filter, error) {
+	if p.peekSymbol("(") {
+		p.next()
+		f, err := p.parseOr()
+		if err != nil {
+			return Filter{}, err
+		}
+		if !p.peekSymbol(")") {
+			return Filter{}, p.errorf("expected \")\", got %s", p.peek())
+		}
+		p.next()
+		return f, nil
+	}
+	return p.parseCondition()
+}
+
+// parseName はプロパティ名またはタイムスタンプを読み取ります
+func (p *queryParser) parseName() (name string, timestamp bool, err error) {
+	if p.peekSymbol("@") {
+		p.next()
+		t := p.next()
+		if t.kind != tokenName || t.text != "created_time" && t.text != "last_edited_time" {
+			p.pos--
+			return "", false, p.errorf("expected @created_time or @last_edited_time")
+		}
+		return t.text, true, nil
+	}
+	t := p.peek()
+	switch {
+	case t.kind == tokenString:
+	case t.kind == tokenName && !slices.Contains(queryKeywords, strings.ToLower(t.text)):
+	default:
+		return "", false, p.errorf("expected property, got %s", t)
+	}
+	p.next()
+	return t.text, false, nil
+}
+
+func (p *queryParser) parseCondition() (Filter, error) {
+	start := p.pos
+	name, timestamp, err := p.parseName()
+	if err != nil {
+		return Filter{}, err
+	}
+
+	if timestamp {
+		op, v, err := p.parseOperator()
+		if err != nil {
+			return Filter{}, err
+		}
+		cond, err := buildQueryCondition("date", op, v)
+		if err != nil {
+			return Filter{}, p.errorAt(start, err)
+		}
+		if name == "created_time" {
+			return Filter{Timestamp: name, CreatedTime: cond.Date}, nil
+		}
+		return Filter{Timestamp: name, LastEditedTime: cond.Date}, nil
+	}
+
+	prop, ok := findSchemaProperty(p.schema, name)
+	if !ok {
+		p.pos = start
+		return Filter{}, p.errorf("property %q does not exist in the database", name)
+	}
+
+	kinds := []string{}
+	for p.peekSymbol(":") {
+		p.next()
+		t := p.next()
+		if t.kind != tokenName {
+			p.pos--
+			return Filter{}, p.errorf("expected condition kind, got %s", t)
+		}
+		kinds = append(kinds, strings.ToLower(t.text))
+	}
+
+	op, v, err := p.parseOperator()
+	if err != nil {
+		return Filter{}, err
+	}
+
+	var cond Filter
+	switch prop.Type {
+	case "formula":
+		cond, err = buildFormulaCondition(kinds, op, v)
+	case "rollup":
+		cond, err = buildRollupCondition(kinds, op, v)
+	default:
+		if len(kinds) != 0 {
+			return Filter{}, p.errorAt(start, fmt.Errorf("%s property %q does not take condition kind", prop.Type, name))
+		}
+		cond, err = buildQueryCondition(filterKindOf(prop.Type), op, v)
+	}
+	if err != nil {
+		return Filter{}, p.errorAt(start, fmt.Errorf("property %q: %w", name, err))
+	}
+	cond.Property = name
+	return cond, nil
+}
+
+func (p *queryParser) errorAt(pos int, err error) error {
+	return fmt.Errorf("query:%d: %w", p.tokens[pos].pos+1, err)
+}
+
+// queryValue はクエリに書かれた値です
+type queryValue struct {
+	token queryToken
+}
+
+func (v *queryValue) String() (string, error) {
+	if v == nil || v.token.kind != tokenString {
+		return "", fmt.Errorf("expected string value")
+	}
+	return v.token.text, nil
+}
+
+func (v *queryValue) Number() (float64, error) {
+	if v == nil || v.token.kind != tokenNumber {
+		return 0, fmt.Errorf("expected number value")
+	}
+	return strconv.ParseFloat(v.token.text, 64)
+}
+
+func (v *queryValue) Bool() (bool, error) {
+	if v != nil && v.token.kind == tokenName {
+		switch strings.ToLower(v.token.text) {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		}
+	}
+	return false, fmt.Errorf("expected true or false")
+}
+
+// parseOperator は演算子と、必要であれば値を読み取ります
+func (p *queryParser) parseOperator() (string, *queryValue, error) {
+	var op string
+	switch t := p.peek(); {
+	case t.kind == tokenSymbol && slices.Contains([]string{"=", "!=", ">", ">=", "<", "<="}, t.text):
+		p.next()
+		op = t.text
+	case p.acceptKeywords("contains"):
+		op = "contains"
+	case p.acceptKeywords("not", "contains"):
+		op = "not contains"
+	case p.acceptKeywords("starts", "with"):
+		op = "starts with"
+	case p.acceptKeywords("ends", "with"):
+		op = "ends with"
+	case p.acceptKeywords("is", "empty"):
+		return "is empty", nil, nil
+	case p.acceptKeywords("is", "not", "empty"):
+		return "is not empty", nil, nil
+	case p.peekKeyword("in"):
+		for _, period := range [][]string{{"past", "week"}, {"past", "month"}, {"past", "year"}, {"next", "week"}, {"next", "month"}, {"next", "year"}, {"this", "week"}} {
+			if p.acceptKeywords("in", period[0], period[1]) {
+				return "in " + period[0] + " " + period[1], nil, nil
+			}
+		}
+		return "", nil, p.errorf("expected period after \"in\"")
+	default:
+		return "", nil, p.errorf("expected operator, got %s", t)
+	}
+
+	t := p.peek()
+	if t.kind == tokenString || t.kind == tokenNumber || p.peekKeyword("true") || p.peekKeyword("false") {
+		p.next()
+		return op, &queryValue{t}, nil
+	}
+	return "", nil, p.errorf("expected value, got %s", t)
+}
+
+func (p *queryParser) parseSorts() ([]Sort, error) {
+	if !p.acceptKeywords("order", "by") {
+		return nil, p.errorf("expected \"order by\"")
+	}
+	sorts := []Sort{}
+	for {
+		start := p.pos
+		name, timestamp, err := p.parseName()
+		if err != nil {
+			return nil, err
+		}
+		s := Sort{Direction: "ascending"}
+		if timestamp {
+			s.Timestamp = name
+		} else {
+			if _, ok := findSchemaProperty(p.schema, name); !ok {
+				p.pos = start
+				return nil, p.errorf("property %q does not exist in the database", name)
+			}
+			s.Property = name
+		}
+		switch {
+		case p.acceptKeywords("asc"):
+		case p.acceptKeywords("desc"):
+			s.Direction = "descending"
+		}
+		sorts = append(sorts, s)
+
+		if !p.peekSymbol(",") {
+			return sorts, nil
+		}
+		p.next()
+	}
+}
+
+// filterKindOf は、種類が typ のプロパティに適用する条件の種類を返します
+func filterKindOf(typ string) string {
+	for kind, types := range filterPropertyTypes {
+		if slices.Contains(types, typ) {
+			return kind
+		}
+	}
+	return typ
+}
+
+// inferQueryKind は、種類が明示されていない formula や rollup の要素の条件の種類を演算子と値から推測します
+func inferQueryKind(op string, v *queryValue) (string, error) {
+	switch {
+	case strings.HasPrefix(op, "in "):
+		return "date", nil
+	case v == nil:
+		return "", fmt.Errorf("cannot infer condition kind for %q, specify it explicitly", op)
+	case v.token.kind == tokenNumber:
+		return "number", nil
+	case v.token.kind == tokenName:
+		return "checkbox", nil
+	default:
+		return "rich_text", nil
+	}
+}
+
+func buildFormulaCondition(kinds []string, op string, v *queryValue) (Filter, error) {
+	if len(kinds) > 1 {
+		return Filter{}, fmt.Errorf("too many condition kinds")
+	}
+	kind := ""
+	if len(kinds) == 1 {
+		kind = map[string]string{"string": "rich_text", "number": "number", "checkbox": "checkbox", "date": "date"}[kinds[0]]
+		if kind == "" {
+			return Filter{}, fmt.Errorf("unknown formula kind %q", kinds[0])
+		}
+	} else {
+		var err error
+		if kind, err = inferQueryKind(op, v); err != nil {
+			return Filter{}, err
+		}
+	}
+
+	cond, err := buildQueryCondition(kind, op, v)
+	if err != nil {
+		return Filter{}, err
+	}
+	return Filter{Formula: &FilterFormula{Checkbox: cond.Checkbox, Date: cond.Date, Number: cond.Number, String: cond.RichText}}, nil
+}
+
+func buildRollupCondition(kinds []string, op string, v *queryValue) (Filter, error) {
+	kind := ""
+	if len(kinds) != 0 {
+		kind = kinds[0]
+	} else if strings.HasPrefix(op, "in ") || v != nil && v.token.kind == tokenString {
+		kind = "date"
+	} else {
+		kind = "number"
+	}
+
+	switch kind {
+	case "number", "date":
+		if len(kinds) > 1 {
+			return Filter{}, fmt.Errorf("too many condition kinds")
+		}
+		cond, err := buildQueryCondition(kind, op, v)
+		if err != nil {
+			return Filter{}, err
+		}
+		return Filter{Rollup: &FilterRollup{Date: cond.Date, Number: cond.Number}}, nil
+
+	case "any", "every", "none":
+		itemKind := ""
+		switch len(kinds) {
+		case 1:
+			var err error
+			if itemKind, err = inferQueryKind(op, v); err != nil {
+				return Filter{}, err
+			}
+		case 2:
+			itemKind = kinds[1]
+		default:
+			return Filter{}, fmt.Errorf("too many condition kinds")
+		}
+		item, err := buildQueryCondition(itemKind, op, v)
+		if err != nil {
+			return Filter{}, err
+		}
+		switch kind {
+		case "any":
+			return Filter{Rollup: &FilterRollup{Any: &item}}, nil
+		case "every":
+			return Filter{Rollup: &FilterRollup{Every: &item}}, nil
+		default:
+			return Filter{Rollup: &FilterRollup{None: &item}}, nil
+		}
+
+	default:
+		return Filter{}, fmt.Errorf("unknown rollup kind %q", kind)
+	}
+}
+
+// buildQueryCondition は、種類が kind の条件を演算子 op と値 v から作成します
+func buildQueryCondition(kind string, op string, v *queryValue) (Filter, error) {
+	unsupported := func() (Filter, error) {
+		return Filter{}, fmt.Errorf("operator %q is not supported for %s condition", op, kind)
+	}
+	isEmpty, isNotEmpty := op == "is empty", op == "is not empty"
+
+	switch kind {
+	case "checkbox":
+		b, err := v.Bool()
+		if err != nil {
+			return unsupported()
+		}
+		switch op {
+		case "=":
+			return Filter{Checkbox: &FilterCheckbox{Equals: &b}}, nil
+		case "!=":
+			return Filter{Checkbox: &FilterCheckbox{DoesNotEqual: &b}}, nil
+		}
+		return unsupported()
+
+	case "date":
+		c := FilterDate{IsEmpty: isEmpty, IsNotEmpty: isNotEmpty}
+		switch op {
+		case "is empty", "is not empty":
+		case "in past week":
+			c.PastWeek = &struct{}{}
+		case "in past month":
+			c.PastMonth = &struct{}{}
+		case "in past year":
+			c.PastYear = &struct{}{}
+		case "in next week":
+			c.NextWeek = &struct{}{}
+		case "in next month":
+			c.NextMonth = &struct{}{}
+		case "in next year":
+			c.NextYear = &struct{}{}
+		case "in this week":
+			c.ThisWeek = &struct{}{}
+		default:
+			s, err := v.String()
+			if err != nil {
+				return Filter{}, err
+			}
+			switch op {
+			case "=":
+				c.Equals = s
+			case "<":
+				c.Before = s
+			case ">":
+				c.After = s
+			case "<=":
+				c.OnOrBefore = s
+			case ">=":
+				c.OnOrAfter = s
+			default:
+				return unsupported()
+			}
+		}
+		return Filter{Date: &c}, nil
+
+	case "files":
+		if !isEmpty && !isNotEmpty {
+			return unsupported()
+		}
+		return Filter{Files: &FilterFiles{IsEmpty: isEmpty, IsNotEmpty: isNotEmpty}}, nil
+
+	case "number":
+		c := FilterNumber{IsEmpty: isEmpty, IsNotEmpty: isNotEmpty}
+		if !isEmpty && !isNotEmpty {
+			n, err := v.Number()
+			if err != nil {
+				return Filter{}, err
+			}
+			switch op {
+			case "=":
+				c.Equals = &n
+			case "!=":
+				c.DoesNotEqual = &n
+			case ">":
+				c.GreaterThan = &n
+			case ">=":
+				c.GreaterThanOrEqualTo = &n
+			case "<":
+				c.LessThan = &n
+			case "<=":
+				c.LessThanOrEqualTo = &n
+			default:
+				return unsupported()
+			}
+		}
+		return Filter{Number: &c}, nil
+
+	case "rich_text":
+		c := FilterRichText{IsEmpty: isEmpty, IsNotEmpty: isNotEmpty}
+		if !isEmpty && !isNotEmpty {
+			s, err := v.String()
+			if err != nil {
+				return Filter{}, err
+			}
+			switch op {
+			case "=":
+				c.Equals = s
+			case "!=":
+				c.DoesNotEqual = s
+			case "contains":
+				c.Contains = s
+			case "not contains":
+				c.DoesNotContain = s
+			case "starts with":
+				c.StartsWith = s
+			case "ends with":
+				c.EndsWith = s
+			default:
+				return unsupported()
+			}
+		}
+		return Filter{RichText: &c}, nil
+
+	case "select", "status":
+		c := FilterSelect{IsEmpty: isEmpty, IsNotEmpty: isNotEmpty}
+		if !isEmpty && !isNotEmpty {
+			s, err := v.String()
+			if err != nil {
+				return Filter{}, err
+			}
+			switch op {
+			case "=":
+				c.Equals = s
+			case "!=":
+				c.DoesNotEqual = s
+			default:
+				return unsupported()
+			}
+		}
+		if kind == "status" {
+			return Filter{Status: &FilterStatus{Equals: c.Equals, DoesNotEqual: c.DoesNotEqual, IsEmpty: c.IsEmpty, IsNotEmpty: c.IsNotEmpty}}, nil
+		}
+		return Filter{Select: &c}, nil
+
+	case "multi_select":
+		c := FilterMultiSelect{IsEmpty: isEmpty, IsNotEmpty: isNotEmpty}
+		if !isEmpty && !isNotEmpty {
+			s, err := v.String()
+			if err != nil {
+				return Filter{}, err
+			}
+			switch op {
+			case "contains":
+				c.Contains = s
+			case "not contains":
+				c.DoesNotContain = s
+			default:
+				return unsupported()
+			}
+		}
+		return Filter{MultiSelect: &c}, nil
+
+	case "people", "relation":
+		var contains, doesNotContain *uuid.UUID
+		if !isEmpty && !isNotEmpty {
+			s, err := v.String()
+			if err != nil {
+				return Filter{}, err
+			}
+			id, err := uuid.Parse(s)
+			if err != nil {
+				return Filter{}, fmt.Errorf("invalid id %q: %w", s, err)
+			}
+			switch op {
+			case "contains":
+				contains = &id
+			case "not contains":
+				doesNotContain = &id
+			default:
+				return unsupported()
+			}
+		}
+		if kind == "people" {
+			return Filter{People: &FilterPeople{Contains: contains, DoesNotContain: doesNotContain, IsEmpty: isEmpty, IsNotEmpty: isNotEmpty}}, nil
+		}
+		return Filter{Relation: &FilterRelation{Contains: contains, DoesNotContain: doesNotContain, IsEmpty: isEmpty, IsNotEmpty: isNotEmpty}}, nil
+
+	default:
+		return Filter{}, fmt.Errorf("unknown condition kind %q", kind)
+	}
+}
+
+// formatQueryName はプロパティ名を、必要であれば引用符で囲んで返します
+func formatQueryName(name string) string {
+	if name == "" || slices.Contains(queryKeywords, strings.ToLower(name)) || strings.IndexFunc(name, func(r rune) bool { return !isQueryNameRune(r) }) != -1 {
+		return strconv.Quote(name)
+	}
+	if _, err := strconv.ParseFloat(name, 64); err == nil {
+		return strconv.Quote(name)
+	}
+	return name
+}
+
+func formatFilter(f Filter) string {
+	switch {
+	case f.And != nil || f.Or != nil:
+		filters, sep := f.And, " and "
+		if f.Or != nil {
+			filters, sep = f.Or, " or "
+		}
+		parts := make([]string, len(filters))
+		for i, sub := range filters {
+			parts[i] = formatFilter(sub)
+			if sub.And != nil || sub.Or != nil {
+				parts[i] = "(" + parts[i] + ")"
+			}
+		}
+		return strings.Join(parts, sep)
+
+	case f.Timestamp == "created_time" && f.CreatedTime != nil:
+		return "@created_time " + formatCondition(Filter{Date: f.CreatedTime})
+	case f.Timestamp == "last_edited_time" && f.LastEditedTime != nil:
+		return "@last_edited_time " + formatCondition(Filter{Date: f.LastEditedTime})
+
+	case f.Formula != nil:
+		c := f.Formula
+		switch {
+		case c.String != nil:
+			return formatQueryName(f.Property) + ":string " + formatCondition(Filter{RichText: c.String})
+		case c.Number != nil:
+			return formatQueryName(f.Property) + ":number " + formatCondition(Filter{Number: c.Number})
+		case c.Checkbox != nil:
+			return formatQueryName(f.Property) + ":checkbox " + formatCondition(Filter{Checkbox: c.Checkbox})
+		case c.Date != nil:
+			return formatQueryName(f.Property) + ":date " + formatCondition(Filter{Date: c.Date})
+		}
+
+	case f.Rollup != nil:
+		c := f.Rollup
+		for kind, item := range map[string]*Filter{"any": c.Any, "every": c.Every, "none": c.None} {
+			if item != nil {
+				itemKind, _ := item.condition()
+				return formatQueryName(f.Property) + ":" + kind + ":" + itemKind + " " + formatCondition(*item)
+			}
+		}
+		switch {
+		case c.Number != nil:
+			return formatQueryName(f.Property) + ":number " + formatCondition(Filter{Number: c.Number})
+		case c.Date != nil:
+			return formatQueryName(f.Property) + ":date " + formatCondition(Filter{Date: c.Date})
+		}
+
+	default:
+		return formatQueryName(f.Property) + " " + formatCondition(f)
+	}
+	return formatQueryName(f.Property) + " <invalid condition>"
+}
+
+// formatCondition は f のプロパティの条件を「演算子 値」の形で返します
+func formatCondition(f Filter) string {
+	quote := strconv.Quote
+	number := func(n float64) string { return strconv.FormatFloat(n, 'g', -1, 64) }
+	emptiness := func(isEmpty, isNotEmpty bool) string {
+		switch {
+		case isEmpty:
+			return "is empty"
+		case isNotEmpty:
+			return "is not empty"
+		}
+		return "<invalid condition>"
+	}
+	ids := func(contains, doesNotContain *uuid.UUID, isEmpty, isNotEmpty bool) string {
+		switch {
+		case contains != nil:
+			return "contains " + quote(contains.String())
+		case doesNotContain != nil:
+			return "not contains " + quote(doesNotContain.String())
+		}
+		return emptiness(isEmpty, isNotEmpty)
+	}
+	options := func(equals, doesNotEqual string, isEmpty, isNotEmpty bool) string {
+		switch {
+		case equals != "":
+			return "= " + quote(equals)
+		case doesNotEqual != "":
+			return "!= " + quote(doesNotEqual)
+		}
+		return emptiness(isEmpty, isNotEmpty)
+	}
+
+	switch {
+	case f.Checkbox != nil:
+		switch c := f.Checkbox; {
+		case c.Equals != nil:
+			return "= " + strconv.FormatBool(*c.Equals)
+		case c.DoesNotEqual != nil:
+			return "!= " + strconv.FormatBool(*c.DoesNotEqual)
+		}
+
+	case f.Date != nil:
+		switch c := f.Date; {
+		case c.Equals != "":
+			return "= " + quote(c.Equals)
+		case c.Before != "":
+			return "< " + quote(c.Before)
+		case c.After != "":
+			return "> " + quote(c.After)
+		case c.OnOrBefore != "":
+			return "<= " + quote(c.OnOrBefore)
+		case c.OnOrAfter != "":
+			return ">= " + quote(c.OnOrAfter)
+		case c.PastWeek != nil:
+			return "in past week"
+		case c.PastMonth != nil:
+			return "in past month"
+		case c.PastYear != nil:
+			return "in past year"
+		case c.NextWeek != nil:
+			return "in next week"
+		case c.NextMonth != nil:
+			return "in next month"
+		case c.NextYear != nil:
+			return "in next year"
+		case c.ThisWeek != nil:
+			return "in this week"
+		default:
+			return emptiness(c.IsEmpty, c.IsNotEmpty)
+		}
+
+	case f.Files != nil:
+		return emptiness(f.Files.IsEmpty, f.Files.IsNotEmpty)
+
+	case f.MultiSelect != nil:
+		switch c := f.MultiSelect; {
+		case c.Contains != "":
+			return "contains " + quote(c.Contains)
+		case c.DoesNotContain != "":
+			return "not contains " + quote(c.DoesNotContain)
+		default:
+			return emptiness(c.IsEmpty, c.IsNotEmpty)
+		}
+
+	case f.Number != nil:
+		switch c := f.Number; {
+		case c.Equals != nil:
+			return "= " + number(*c.Equals)
+		case c.DoesNotEqual != nil:
+			return "!= " + number(*c.DoesNotEqual)
+		case c.GreaterThan != nil:
+			return "> " + number(*c.GreaterThan)
+		case c.GreaterThanOrEqualTo != nil:
+			return ">= " + number(*c.GreaterThanOrEqualTo)
+		case c.LessThan != nil:
+			return "< " + number(*c.LessThan)
+		case c.LessThanOrEqualTo != nil:
+			return "<= " + number(*c.LessThanOrEqualTo)
+		default:
+			return emptiness(c.IsEmpty, c.IsNotEmpty)
+		}
+
+	case f.People != nil:
+		return ids(f.People.Contains, f.People.DoesNotContain, f.People.IsEmpty, f.People.IsNotEmpty)
+
+	case f.Relation != nil:
+		return ids(f.Relation.Contains, f.Relation.DoesNotContain, f.Relation.IsEmpty, f.Relation.IsNotEmpty)
+
+	case f.RichText != nil:
+		switch c := f.RichText; {
+		case c.Equals != "":
+			return "= " + quote(c.Equals)
+		case c.DoesNotEqual != "":
+			return "!= " + quote(c.DoesNotEqual)
+		case c.Contains != "":
+			return "contains " + quote(c.Contains)
+		case c.DoesNotContain != "":
+			return "not contains " + quote(c.DoesNotContain)
+		case c.StartsWith != "":
+			return "starts with " + quote(c.StartsWith)
+		case c.EndsWith != "":
+			return "ends with " + quote(c.EndsWith)
+		default:
+			return emptiness(c.IsEmpty, c.IsNotEmpty)
+		}
+
+	case f.Select != nil:
+		return options(f.Select.Equals, f.Select.DoesNotEqual, f.Select.IsEmpty, f.Select.IsNotEmpty)
+
+	case f.Status != nil:
+		return options(f.Status.Equals, f.Status.DoesNotEqual, f.Status.IsEmpty, f.Status.IsNotEmpty)
+	}
+	return "<invalid condition>"
+}
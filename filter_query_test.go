@@ -0,0 +1,103 @@
+package notion
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseQuery(t *testing.T) {
+	db := &Database{Properties: map[string]Property{
+		"Name":     {Type: "title", Id: "title"},
+		"Status":   {Type: "status", Id: "a"},
+		"Tags":     {Type: "multi_select", Id: "b"},
+		"Due":      {Type: "date", Id: "c"},
+		"Points":   {Type: "number", Id: "d"},
+		"Done":     {Type: "checkbox", Id: "e"},
+		"Owner":    {Type: "people", Id: "f"},
+		"Calc":     {Type: "formula", Id: "g"},
+		"Sum":      {Type: "rollup", Id: "h"},
+		"Due Date": {Type: "date", Id: "i"},
+		"担当":       {Type: "rich_text", Id: "j"},
+	}}
+
+	t.Run("example", func(t *testing.T) {
+		filter, sorts, err := ParseQuery(`Status = "Done" and (Tags contains "A" or Tags contains "B") order by Due desc`, db)
+		if assert.NoError(t, err) {
+			assert.Equal(t, &Filter{And: []Filter{
+				{Property: "Status", Status: &FilterStatus{Equals: "Done"}},
+				{Or: []Filter{
+					{Property: "Tags", MultiSelect: &FilterMultiSelect{Contains: "A"}},
+					{Property: "Tags", MultiSelect: &FilterMultiSelect{Contains: "B"}},
+				}},
+			}}, filter)
+			assert.Equal(t, []Sort{{Property: "Due", Direction: "descending"}}, sorts)
+		}
+	})
+
+	t.Run("conditions", func(t *testing.T) {
+		ownerId := uuid.MustParse("5d0a5b8e-9b7c-4b0e-8f0a-1c2d3e4f5a6b")
+		tests := map[string]Filter{
+			`Points >= 1.5`:                             {Property: "Points", Number: &FilterNumber{GreaterThanOrEqualTo: lo.ToPtr(1.5)}},
+			`Done = true`:                               {Property: "Done", Checkbox: &FilterCheckbox{Equals: lo.ToPtr(true)}},
+			`Due < "2024-01-01"`:                        {Property: "Due", Date: &FilterDate{Before: "2024-01-01"}},
+			`"Due Date" in next month`:                  {Property: "Due Date", Date: &FilterDate{NextMonth: &struct{}{}}},
+			`@created_time in past week`:                {Timestamp: "created_time", CreatedTime: &FilterDate{PastWeek: &struct{}{}}},
+			`Name starts with "a"`:                      {Property: "Name", RichText: &FilterRichText{StartsWith: "a"}},
+			`担当 is not empty`:                           {Property: "担当", RichText: &FilterRichText{IsNotEmpty: true}},
+			`Owner contains "` + ownerId.String() + `"`: {Property: "Owner", People: &FilterPeople{Contains: &ownerId}},
+			`Calc = 3`:                                  {Property: "Calc", Formula: &FilterFormula{Number: &FilterNumber{Equals: lo.ToPtr(3.0)}}},
+			`Calc:date = "2024-01-01"`:                  {Property: "Calc", Formula: &FilterFormula{Date: &FilterDate{Equals: "2024-01-01"}}},
+			`Sum:any:select = "A"`:                      {Property: "Sum", Rollup: &FilterRollup{Any: &Filter{Select: &FilterSelect{Equals: "A"}}}},
+			`Sum > 10`:                                  {Property: "Sum", Rollup: &FilterRollup{Number: &FilterNumber{GreaterThan: lo.ToPtr(10.0)}}},
+		}
+		for query, want := range tests {
+			filter, sorts, err := ParseQuery(query, db)
+			if assert.NoError(t, err, query) {
+				assert.Equal(t, &want, filter, query)
+				assert.Nil(t, sorts)
+			}
+		}
+	})
+
+	t.Run("sorts only", func(t *testing.T) {
+		filter, sorts, err := ParseQuery(`order by Points, @last_edited_time desc`, db)
+		if assert.NoError(t, err) {
+			assert.Nil(t, filter)
+			assert.Equal(t, []Sort{{Property: "Points", Direction: "ascending"}, {Timestamp: "last_edited_time", Direction: "descending"}}, sorts)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		for _, query := range []string{
+			`Missing = "a"`,
+			`Points = "a"`,
+			`Tags = "A"`,
+			`Status = "Done" and`,
+			`(Done = true`,
+			`Name = "unterminated`,
+			`Calc is empty`,
+			`Done = true order by`,
+			`Done = true extra`,
+		} {
+			_, _, err := ParseQuery(query, db)
+			assert.Error(t, err, query)
+		}
+	})
+
+	t.Run("FormatQuery", func(t *testing.T) {
+		for _, query := range []string{
+			`Status = "Done" and (Tags contains "A" or Tags contains "B") order by Due desc`,
+			`"Due Date" >= "2024-01-01" or @created_time in this week`,
+			`Calc:string ends with "\"x\"" and Sum:every:number < 2`,
+			`担当 is empty order by Points, @last_edited_time desc`,
+		} {
+			filter, sorts, err := ParseQuery(query, db)
+			if assert.NoError(t, err, query) {
+				assert.Equal(t, query, FormatQuery(filter, sorts))
+			}
+		}
+	})
+}
@@ -1,6 +1,6 @@
 module github.com/psyark/notion
 
-go 1.22
+go 1.23
 
 require (
 	github.com/dave/jennifer v1.7.0
@@ -10,6 +10,7 @@ require (
 	github.com/pkg/errors v0.9.1
 	github.com/samber/lo v1.46.0
 	github.com/stretchr/testify v1.3.0
+	github.com/yuin/goldmark v1.7.4
 )
 
 require (
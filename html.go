@@ -0,0 +1,574 @@
+package notion
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// HTMLHook は RenderHTML の出力をカスタマイズします
+// 既定の動作を変更しないメソッドは DefaultHTMLHook を埋め込んで省略できます
+type HTMLHook interface {
+	// RenderBlock は block を独自に出力する場合に、そのHTMLと true を返します
+	// false を返した場合は既定の方法で出力されます。children は block の子ブロックを既定の方法で出力します
+	RenderBlock(block Block, children func() ([]byte, error)) ([]byte, bool, error)
+	// FileURL は file を出力する際のURLを返します（期限付きの FileFile.Url の置き換えなどに使用します）
+	FileURL(file File) (string, error)
+}
+
+// DefaultHTMLHook は既定の動作を行う HTMLHook です
+type DefaultHTMLHook struct{}
+
+func (DefaultHTMLHook) RenderBlock(block Block, children func() ([]byte, error)) ([]byte, bool, error) {
+	return nil, false, nil
+}
+
+func (DefaultHTMLHook) FileURL(file File) (string, error) {
+	switch {
+	case file.File != nil:
+		return file.File.Url, nil
+	case file.External != nil:
+		return file.External.Url, nil
+	}
+	return "", nil
+}
+
+var _ HTMLHook = DefaultHTMLHook{}
+
+// HTMLOption は RenderHTML の動作を変更します
+type HTMLOption func(r *htmlRenderer)
+
+// WithHTMLHook は RenderHTML の出力をカスタマイズする hook を設定します
+func WithHTMLHook(hook HTMLHook) HTMLOption {
+	return func(r *htmlRenderer) {
+		r.hook = hook
+	}
+}
+
+// WithBlockChildren は、ブロックのIDをキーとする子ブロックを RenderHTML に渡します
+// Children フィールドを持たないブロック（column_list、column など）や、別途取得した子ブロックを出力するために使用します
+func WithBlockChildren(children map[uuid.UUID][]Block) HTMLOption {
+	return func(r *htmlRenderer) {
+		r.children = children
+	}
+}
+
+// WithSyncedBlockResolver は、同期ブロックの複製の出力に使用する、元の同期ブロックの子ブロックを返す関数を設定します
+// 元の同期ブロックが RenderHTML に渡したブロックに含まれる場合は、この関数は呼ばれません
+func WithSyncedBlockResolver(resolve func(blockId uuid.UUID) ([]Block, error)) HTMLOption {
+	return func(r *htmlRenderer) {
+		r.resolveSynced = resolve
+	}
+}
+
+/*
+RenderHTML は blocks をHTMLに変換します
+
+テキストは全てエスケープされ、ブロックは意味に応じた要素（p、h1〜h3、ul、pre など）に変換されます。
+Annotations.Color とブロックの Color は notion-color-{色} のCSSクラスとして出力されます（英小文字と "_" 以外を含む色は出力されません）。
+ページとデータベースのメンションはリンクに、ユーザーは span に、日付は time 要素に変換されます。
+同期ブロックの複製は元の同期ブロックの内容で出力され、カラムは flex コンテナとして出力されます。
+リンクや画像のURLは相対URLと http・https・mailto スキームのみが出力され、それ以外（javascript: など）は取り除かれます。
+*/
+func RenderHTML(blocks []Block, options ...HTMLOption) ([]byte, error) {
+	r := &htmlRenderer{hook: DefaultHTMLHook{}, synced: map[uuid.UUID]Block{}}
+	for _, option := range options {
+		option(r)
+	}
+	r.collectSynced(blocks)
+
+	buf := &bytes.Buffer{}
+	if err := r.blocks(buf, blocks); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+type htmlRenderer struct {
+	hook          HTMLHook
+	children      map[uuid.UUID][]Block
+	resolveSynced func(blockId uuid.UUID) ([]Block, error)
+	synced        map[uuid.UUID]Block // 元の同期ブロック
+}
+
+// collectSynced は blocks に含まれる元の同期ブロックを収集します
+func (r *htmlRenderer) collectSynced(blocks []Block) {
+	for _, block := range blocks {
+		if block.SyncedBlock != nil && block.SyncedBlock.SyncedFrom == nil && block.Id != uuid.Nil {
+			r.synced[block.Id] = block
+		}
+		r.collectSynced(r.childrenOf(block))
+	}
+}
+
+// childrenOf は block の子ブロックを返します
+func (r *htmlRenderer) childrenOf(block Block) []Block {
+	if children, ok := r.children[block.Id]; ok && block.Id != uuid.Nil {
+		return children
+	}
+	if children := block.ChildrenField(); children != nil {
+		return *children
+	}
+	return nil
+}
+
+// blocks は blocks を出力します。連続するリスト項目は1つのリストにまとめられます
+func (r *htmlRenderer) blocks(buf *bytes.Buffer, blocks []Block) error {
+	list, closeList := "", ""
+	for _, block := range blocks {
+		tag, closeTag := "", ""
+		switch {
+		case block.BulletedListItem != nil:
+			tag, closeTag = `<ul>`, "</ul>"
+		case block.NumberedListItem != nil:
+			tag, closeTag = `<ol>`, "</ol>"
+		case block.ToDo != nil:
+			tag, closeTag = `<ul class="notion-to-do">`, "</ul>"
+		}
+		if tag != list {
+			buf.WriteString(closeList)
+			buf.WriteString(tag)
+			list, closeList = tag, closeTag
+		}
+		if err := r.block(buf, block); err != nil {
+			return err
+		}
+	}
+	buf.WriteString(closeList)
+	return nil
+}
+
+func (r *htmlRenderer) block(buf *bytes.Buffer, block Block) error {
+	children := func() ([]byte, error) {
+		buf := &bytes.Buffer{}
+		err := r.blocks(buf, r.childrenOf(block))
+		return buf.Bytes(), err
+	}
+
+	if data, ok, err := r.hook.RenderBlock(block, children); err != nil {
+		return err
+	} else if ok {
+		buf.Write(data)
+		return nil
+	}
+
+	writeChildren := func() error {
+		data, err := children()
+		buf.Write(data)
+		return err
+	}
+	open := func(tag string, class string, color string) {
+		class = strings.TrimSpace(class + " " + colorClass(color))
+		if class == "" {
+			fmt.Fprintf(buf, "<%s>", tag)
+		} else {
+			fmt.Fprintf(buf, `<%s class="%s">`, tag, class)
+		}
+	}
+
+	switch {
+	case block.Paragraph != nil:
+		open("p", "", block.Paragraph.Color)
+		r.richText(buf, block.Paragraph.RichText)
+		buf.WriteString("</p>")
+		if len(r.childrenOf(block)) != 0 {
+			buf.WriteString(`<div class="notion-indent">`)
+			if err := writeChildren(); err != nil {
+				return err
+			}
+			buf.WriteString("</div>")
+		}
+
+	case block.Heading1 != nil, block.Heading2 != nil, block.Heading3 != nil:
+		tag, heading := "h1", block.Heading1
+		if block.Heading2 != nil {
+			tag, heading = "h2", block.Heading2
+		} else if block.Heading3 != nil {
+			tag, heading = "h3", block.Heading3
+		}
+		toggleable := heading.IsToggleable != nil && *heading.IsToggleable
+		if toggleable {
+			buf.WriteString("<details><summary>")
+		}
+		open(tag, "", heading.Color)
+		r.richText(buf, heading.RichText)
+		fmt.Fprintf(buf, "</%s>", tag)
+		if toggleable {
+			buf.WriteString("</summary>")
+			if err := writeChildren(); err != nil {
+				return err
+			}
+			buf.WriteString("</details>")
+		}
+
+	case block.BulletedListItem != nil, block.NumberedListItem != nil:
+		rta, color := RichTextArray(nil), ""
+		if block.BulletedListItem != nil {
+			rta, color = block.BulletedListItem.RichText, block.BulletedListItem.Color
+		} else {
+			rta, color = block.NumberedListItem.RichText, block.NumberedListItem.Color
+		}
+		open("li", "", color)
+		r.richText(buf, rta)
+		if err := writeChildren(); err != nil {
+			return err
+		}
+		buf.WriteString("</li>")
+
+	case block.ToDo != nil:
+		open("li", "", block.ToDo.Color)
+		if block.ToDo.Checked != nil && *block.ToDo.Checked {
+			buf.WriteString(`<input type="checkbox" disabled checked> `)
+		} else {
+			buf.WriteString(`<input type="checkbox" disabled> `)
+		}
+		r.richText(buf, block.ToDo.RichText)
+		if err := writeChildren(); err != nil {
+			return err
+		}
+		buf.WriteString("</li>")
+
+	case block.Quote != nil:
+		open("blockquote", "", block.Quote.Color)
+		r.richText(buf, block.Quote.RichText)
+		if err := writeChildren(); err != nil {
+			return err
+		}
+		buf.WriteString("</blockquote>")
+
+	case block.Toggle != nil:
+		open("details", "notion-toggle", block.Toggle.Color)
+		buf.WriteString("<summary>")
+		r.richText(buf, block.Toggle.RichText)
+		buf.WriteString("</summary>")
+		if err := writeChildren(); err != nil {
+			return err
+		}
+		buf.WriteString("</details>")
+
+	case block.Table != nil:
+		r.table(buf, block.Table, r.childrenOf(block))
+
+	case block.Code != nil:
+		buf.WriteString("<figure>")
+		if block.Code.Language != "" && block.Code.Language != "plain text" {
+			fmt.Fprintf(buf, `<pre><code class="language-%s">`, html.EscapeString(strings.ReplaceAll(block.Code.Language, " ", "-")))
+		} else {
+			buf.WriteString("<pre><code>")
+		}
+		buf.WriteString(html.EscapeString(block.Code.RichText.String()))
+		buf.WriteString("</code></pre>")
+		r.caption(buf, block.Code.Caption)
+		buf.WriteString("</figure>")
+
+	case block.Callout != nil:
+		open("aside", "notion-callout", block.Callout.Color)
+		switch icon := block.Callout.Icon.(type) {
+		case *Emoji:
+			fmt.Fprintf(buf, `<span class="notion-callout-icon">%s</span>`, html.EscapeString(icon.Emoji))
+		case *File:
+			url, err := r.hook.FileURL(*icon)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(buf, `<img class="notion-callout-icon" src="%s" alt="">`, html.EscapeString(safeURL(url)))
+		}
+		buf.WriteString("<div>")
+		r.richText(buf, block.Callout.RichText)
+		if err := writeChildren(); err != nil {
+			return err
+		}
+		buf.WriteString("</div></aside>")
+
+	case block.Equation != nil:
+		fmt.Fprintf(buf, `<div class="notion-equation">%s</div>`, html.EscapeString(block.Equation.Expression))
+
+	case block.Divider != nil:
+		buf.WriteString("<hr>")
+
+	case block.Image != nil:
+		url, err := r.hook.FileURL(*block.Image)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(buf, `<figure><img src="%s" alt="%s">`, html.EscapeString(safeURL(url)), html.EscapeString(block.Image.Caption.String()))
+		r.caption(buf, block.Image.Caption)
+		buf.WriteString("</figure>")
+
+	case block.Video != nil, block.Audio != nil:
+		file, tag := block.Video, "video"
+		if block.Audio != nil {
+			file, tag = block.Audio, "audio"
+		}
+		url, err := r.hook.FileURL(*file)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(buf, `<figure><%s controls src="%s"></%s>`, tag, html.EscapeString(safeURL(url)), tag)
+		r.caption(buf, file.Caption)
+		buf.WriteString("</figure>")
+
+	case block.File != nil, block.Pdf != nil:
+		file, class := block.File, "notion-file"
+		if block.Pdf != nil {
+			file, class = &File{Type: block.Pdf.Type, File: block.Pdf.File, External: block.Pdf.External, Caption: block.Pdf.Caption}, "notion-pdf"
+		}
+		url, err := r.hook.FileURL(*file)
+		if err != nil {
+			return err
+		}
+		r.link(buf, class, url, file.Caption, file.Name)
+
+	case block.Bookmark != nil:
+		r.link(buf, "notion-bookmark", block.Bookmark.Url, block.Bookmark.Caption, "")
+
+	case block.Embed != nil:
+		r.link(buf, "notion-embed", block.Embed.Url, nil, "")
+
+	case block.LinkPreview != nil:
+		r.link(buf, "notion-link-preview", block.LinkPreview.Url, nil, "")
+
+	case block.LinkToPage != nil:
+		id := block.LinkToPage.PageId
+		if block.LinkToPage.Type == "database_id" {
+			id = block.LinkToPage.DatabaseId
+		}
+		r.link(buf, "notion-link-to-page", notionURL(id), nil, "")
+
+	case block.ChildPage != nil:
+		fmt.Fprintf(buf, `<p class="notion-child-page"><a href="%s">%s</a></p>`, notionURL(block.Id), html.EscapeString(block.ChildPage.Title))
+
+	case block.ChildDatabase != nil:
+		fmt.Fprintf(buf, `<p class="notion-child-database"><a href="%s">%s</a></p>`, notionURL(block.Id), html.EscapeString(block.ChildDatabase.Title))
+
+	case block.ColumnList != nil:
+		buf.WriteString(`<div class="notion-column-list" style="display:flex;gap:1em">`)
+		if err := writeChildren(); err != nil {
+			return err
+		}
+		buf.WriteString("</div>")
+
+	case block.Column != nil:
+		buf.WriteString(`<div class="notion-column" style="flex:1;min-width:0">`)
+		if err := writeChildren(); err != nil {
+			return err
+		}
+		buf.WriteString("</div>")
+
+	case block.SyncedBlock != nil:
+		buf.WriteString(`<div class="notion-synced-block">`)
+		if from := block.SyncedBlock.SyncedFrom; from != nil && len(r.childrenOf(block)) == 0 {
+			children, err := r.syncedChildren(from.BlockId)
+			if err != nil {
+				return err
+			}
+			if err := r.blocks(buf, children); err != nil {
+				return err
+			}
+		} else if err := writeChildren(); err != nil {
+			return err
+		}
+		buf.WriteString("</div>")
+
+	case block.Template != nil, block.TableOfContents != nil, block.TableRow != nil:
+		// テンプレートボタンと目次は出力しません。表の行は table の一部として出力されます
+
+	default:
+		// breadcrumb など、HTMLで表現できないブロックは子ブロックのみを出力します
+		return writeChildren()
+	}
+	return nil
+}
+
+// table は表を出力します。rows は表の子ブロック（table_row）です
+func (r *htmlRenderer) table(buf *bytes.Buffer, table *BlockTable, rows []Block) {
+	buf.WriteString(`<table class="notion-table">`)
+	columnHeader := table.HasColumnHeader != nil && *table.HasColumnHeader
+	rowHeader := table.HasRowHeader != nil && *table.HasRowHeader
+	body := false
+	for i, row := range rows {
+		if row.TableRow == nil {
+			continue
+		}
+		header := i == 0 && columnHeader
+		if header {
+			buf.WriteString("<thead>")
+		} else if !body {
+			buf.WriteString("<tbody>")
+			body = true
+		}
+		buf.WriteString("<tr>")
+		for j, cell := range row.TableRow.Cells {
+			switch {
+			case header:
+				buf.WriteString(`<th scope="col">`)
+			case j == 0 && rowHeader:
+				buf.WriteString(`<th scope="row">`)
+			default:
+				buf.WriteString("<td>")
+			}
+			r.richText(buf, cell)
+			if header || j == 0 && rowHeader {
+				buf.WriteString("</th>")
+			} else {
+				buf.WriteString("</td>")
+			}
+		}
+		buf.WriteString("</tr>")
+		if header {
+			buf.WriteString("</thead>")
+		}
+	}
+	if body {
+		buf.WriteString("</tbody>")
+	}
+	buf.WriteString("</table>")
+}
+
+// syncedChildren は元の同期ブロック id の子ブロックを返します
+func (r *htmlRenderer) syncedChildren(id uuid.UUID) ([]Block, error) {
+	if original, ok := r.synced[id]; ok {
+		return r.childrenOf(original), nil
+	}
+	if r.resolveSynced != nil {
+		return r.resolveSynced(id)
+	}
+	return nil, nil
+}
+
+func (r *htmlRenderer) caption(buf *bytes.Buffer, caption RichTextArray) {
+	if len(caption) != 0 {
+		buf.WriteString("<figcaption>")
+		r.richText(buf, caption)
+		buf.WriteString("</figcaption>")
+	}
+}
+
+// link はファイルやブックマークへのリンクを出力します
+// リンクのテキストはキャプション、名前、URLの順に空でないものを使用します
+// url が安全でない場合はリンクにせず、テキストのみを出力します
+func (r *htmlRenderer) link(buf *bytes.Buffer, class string, url string, caption RichTextArray, name string) {
+	text := &bytes.Buffer{}
+	switch {
+	case len(caption) != 0:
+		r.richText(text, caption)
+	case name != "":
+		text.WriteString(html.EscapeString(name))
+	default:
+		text.WriteString(html.EscapeString(url))
+	}
+	fmt.Fprintf(buf, `<p class="%s">%s</p>`, class, anchor("", url, text.String()))
+}
+
+// richText はリッチテキストをインライン要素として出力します
+func (r *htmlRenderer) richText(buf *bytes.Buffer, rta RichTextArray) {
+	for _, rt := range rta {
+		r.span(buf, rt)
+	}
+}
+
+func (r *htmlRenderer) span(buf *bytes.Buffer, rt RichText) {
+	text := strings.ReplaceAll(html.EscapeString(rt.PlainText), "\n", "<br>")
+
+	var content string
+	switch {
+	case rt.Equation != nil:
+		content = `<span class="notion-equation">` + html.EscapeString(rt.Equation.Expression) + `</span>`
+	case rt.Mention != nil:
+		m := rt.Mention
+		switch {
+		case m.User != nil:
+			content = fmt.Sprintf(`<span class="notion-mention-user" data-user-id="%s">%s</span>`, m.User.Id, text)
+		case m.Date != nil:
+			content = fmt.Sprintf(`<time class="notion-mention-date" datetime="%s">%s</time>`, html.EscapeString(m.Date.Start), text)
+		case m.Page != nil:
+			content = anchor("notion-mention-page", hrefOr(rt.Href, notionURL(m.Page.Id)), text)
+		case m.Database != nil:
+			content = anchor("notion-mention-database", hrefOr(rt.Href, notionURL(m.Database.Id)), text)
+		case m.LinkPreview != nil:
+			content = anchor("notion-mention-link-preview", m.LinkPreview.Url, text)
+		default:
+			content = `<span class="notion-mention">` + text + `</span>`
+		}
+	default:
+		content = text
+	}
+
+	a := rt.Annotations
+	if a.Code {
+		content = "<code>" + content + "</code>"
+	}
+	if a.Underline {
+		content = "<u>" + content + "</u>"
+	}
+	if a.Strikethrough {
+		content = "<s>" + content + "</s>"
+	}
+	if a.Italic {
+		content = "<em>" + content + "</em>"
+	}
+	if a.Bold {
+		content = "<strong>" + content + "</strong>"
+	}
+	if class := colorClass(a.Color); class != "" {
+		content = `<span class="` + class + `">` + content + `</span>`
+	}
+	if rt.Text != nil && rt.Href != nil && *rt.Href != "" {
+		content = anchor("", *rt.Href, content)
+	}
+	buf.WriteString(content)
+}
+
+// colorClass は色を表すCSSクラスを返します。既定の色の場合は空文字列を返します
+// 色の名前は英小文字と "_" のみからなる必要があり、それ以外の文字を含む場合は属性に出力せず空文字列を返します
+func colorClass(color string) string {
+	if color == "" || color == "default" {
+		return ""
+	}
+	if strings.ContainsFunc(color, func(r rune) bool { return (r < 'a' || 'z' < r) && r != '_' }) {
+		return ""
+	}
+	return "notion-color-" + color
+}
+
+// allowedSchemes は、RenderHTML が出力するリンクや画像のURLに許可されるスキームです
+var allowedSchemes = []string{"http", "https", "mailto"}
+
+// safeURL は、u が allowedSchemes のいずれかのスキームを持つか相対URLの場合は u を返し、
+// それ以外（javascript: など）の場合は空文字列を返します
+func safeURL(u string) string {
+	parsed, err := url.Parse(u)
+	if err != nil || (parsed.Scheme != "" && !slices.Contains(allowedSchemes, parsed.Scheme)) {
+		return ""
+	}
+	return u
+}
+
+// anchor は content を href へのリンクにします。href が安全でない場合は content をそのまま返します
+func anchor(class string, href string, content string) string {
+	href = safeURL(href)
+	if href == "" {
+		return content
+	}
+	if class != "" {
+		return `<a class="` + class + `" href="` + html.EscapeString(href) + `">` + content + `</a>`
+	}
+	return `<a href="` + html.EscapeString(href) + `">` + content + `</a>`
+}
+
+func hrefOr(href *string, fallback string) string {
+	if href != nil && *href != "" {
+		return *href
+	}
+	return fallback
+}
+
+// notionURL は id のページまたはブロックのNotion上のURLを返します
+func notionURL(id uuid.UUID) string {
+	return "https://www.notion.so/" + strings.ReplaceAll(id.String(), "-", "")
+}
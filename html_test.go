@@ -0,0 +1,152 @@
+package notion
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+type testHTMLHook struct {
+	DefaultHTMLHook
+}
+
+func (testHTMLHook) RenderBlock(block Block, children func() ([]byte, error)) ([]byte, bool, error) {
+	if block.Divider != nil {
+		return []byte("<hr class=\"custom\">"), true, nil
+	}
+	return nil, false, nil
+}
+
+func (testHTMLHook) FileURL(file File) (string, error) {
+	return "https://cdn.example.com/" + file.Name, nil
+}
+
+func TestRenderHTML(t *testing.T) {
+	text := func(s string) RichText {
+		return RichText{Type: "text", PlainText: s, Text: &RichTextText{Content: s}}
+	}
+	pageId := uuid.MustParse("11111111-2222-3333-4444-555555555555")
+	syncedId := uuid.MustParse("aaaaaaaa-2222-3333-4444-555555555555")
+
+	bold := text("<b>")
+	bold.Annotations.Bold = true
+	bold.Annotations.Color = "red"
+	link := text("link")
+	link.Href = lo.ToPtr("https://example.com/?a=1&b=2")
+
+	blocks := []Block{
+		{Paragraph: &BlockParagraph{Color: "blue_background", RichText: RichTextArray{bold, link}}},
+		{Paragraph: &BlockParagraph{RichText: RichTextArray{
+			{Type: "mention", PlainText: "Page", Mention: &Mention{Type: "page", Page: &PageReference{Id: pageId}}},
+			{Type: "mention", PlainText: "@Alice", Mention: &Mention{Type: "user", User: &User{Id: pageId}}},
+			{Type: "mention", PlainText: "2024-01-02", Mention: &Mention{Type: "date", Date: &PropertyValueDate{Start: "2024-01-02"}}},
+		}}},
+		{BulletedListItem: &BlockBulletedListItem{RichText: RichTextArray{text("a")}}},
+		{BulletedListItem: &BlockBulletedListItem{RichText: RichTextArray{text("b")}}},
+		{ToDo: &BlockToDo{RichText: RichTextArray{text("c")}, Checked: lo.ToPtr(true)}},
+		{Divider: &struct{}{}},
+		{Image: &File{Type: "file", Name: "i.png", File: &FileFile{Url: "https://s3.example.com/i.png?expires"}}},
+		{Id: syncedId, SyncedBlock: &BlockSyncedBlock{Children: []Block{{Paragraph: &BlockParagraph{RichText: RichTextArray{text("synced")}}}}}},
+		{SyncedBlock: &BlockSyncedBlock{SyncedFrom: &SyncedFrom{BlockId: syncedId}}},
+		{Id: pageId, ColumnList: &BlockColumnList{}},
+	}
+	columns := map[uuid.UUID][]Block{
+		pageId: {{Column: &BlockColumn{}}},
+	}
+
+	data, err := RenderHTML(blocks, WithHTMLHook(testHTMLHook{}), WithBlockChildren(columns))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, ``+
+		`<p class="notion-color-blue_background"><span class="notion-color-red"><strong>&lt;b&gt;</strong></span><a href="https://example.com/?a=1&amp;b=2">link</a></p>`+
+		`<p><a class="notion-mention-page" href="https://www.notion.so/11111111222233334444555555555555">Page</a>`+
+		`<span class="notion-mention-user" data-user-id="11111111-2222-3333-4444-555555555555">@Alice</span>`+
+		`<time class="notion-mention-date" datetime="2024-01-02">2024-01-02</time></p>`+
+		`<ul><li>a</li><li>b</li></ul>`+
+		`<ul class="notion-to-do"><li><input type="checkbox" disabled checked> c</li></ul>`+
+		`<hr class="custom">`+
+		`<figure><img src="https://cdn.example.com/i.png" alt=""></figure>`+
+		`<div class="notion-synced-block"><p>synced</p></div>`+
+		`<div class="notion-synced-block"><p>synced</p></div>`+
+		`<div class="notion-column-list" style="display:flex;gap:1em"><div class="notion-column" style="flex:1;min-width:0"></div></div>`,
+		string(data))
+
+	t.Run("resolver", func(t *testing.T) {
+		data, err := RenderHTML(blocks[8:9], WithSyncedBlockResolver(func(id uuid.UUID) ([]Block, error) {
+			return []Block{{Divider: &struct{}{}}}, nil
+		}))
+		if assert.NoError(t, err) {
+			assert.Equal(t, `<div class="notion-synced-block"><hr></div>`, string(data))
+		}
+	})
+
+	t.Run("unsafe urls", func(t *testing.T) {
+		script := "javascript:alert(1)"
+		blocks := []Block{
+			{Paragraph: &BlockParagraph{RichText: RichTextArray{
+				{Text: &RichTextText{Content: "x"}, PlainText: "x", Href: &script},
+				{Text: &RichTextText{Content: "y"}, PlainText: "y", Href: lo.ToPtr("mailto:a@example.com")},
+				{Mention: &Mention{LinkPreview: &MentionLinkPreview{Url: "JavaScript:alert(1)"}}, PlainText: "z"},
+			}}},
+			{Bookmark: &BlockBookmark{Url: script}},
+			{Embed: &BlockEmbed{Url: " javascript:alert(1)"}},
+			{Image: &File{External: &FileExternal{Url: "data:image/svg+xml,<svg/>"}}},
+		}
+		data, err := RenderHTML(blocks)
+		if assert.NoError(t, err) {
+			assert.Equal(t, ``+
+				`<p>x<a href="mailto:a@example.com">y</a>z</p>`+
+				`<p class="notion-bookmark">javascript:alert(1)</p>`+
+				`<p class="notion-embed"> javascript:alert(1)</p>`+
+				`<figure><img src="" alt=""></figure>`,
+				string(data))
+		}
+	})
+
+	t.Run("unsafe colors", func(t *testing.T) {
+		color := `red" onmouseover="alert(1)`
+		blocks := []Block{
+			{Paragraph: &BlockParagraph{Color: color, RichText: RichTextArray{
+				{Text: &RichTextText{Content: "x"}, PlainText: "x", Annotations: Annotations{Color: color}},
+			}}},
+			{Toggle: &BlockToggle{Color: color, RichText: NewRichTextArray("y")}},
+		}
+		data, err := RenderHTML(blocks)
+		if assert.NoError(t, err) {
+			assert.Equal(t, ``+
+				`<p>x</p>`+
+				`<details class="notion-toggle"><summary>y</summary></details>`,
+				string(data))
+		}
+	})
+
+	t.Run("block types", func(t *testing.T) {
+		cells := func(texts ...string) []RichTextArray {
+			return lo.Map(texts, func(s string, _ int) RichTextArray { return RichTextArray{text(s)} })
+		}
+		blocks := []Block{
+			{NumberedListItem: &BlockNumberedListItem{RichText: RichTextArray{text("1")}}},
+			{NumberedListItem: &BlockNumberedListItem{RichText: RichTextArray{text("2")}}},
+			{Quote: &BlockQuote{RichText: RichTextArray{text("q")}, Color: "gray"}},
+			{Toggle: &BlockToggle{RichText: RichTextArray{text("t")}, Children: []Block{{Divider: &struct{}{}}}}},
+			{Table: &BlockTable{TableWidth: 2, HasColumnHeader: lo.ToPtr(true), HasRowHeader: lo.ToPtr(true), Children: []Block{
+				{TableRow: &BlockTableRow{Cells: cells("", "A")}},
+				{TableRow: &BlockTableRow{Cells: cells("x", "1")}},
+			}}},
+			{Type: "unsupported", UnknownPayload: []byte(`{}`)},
+		}
+		data, err := RenderHTML(blocks)
+		if assert.NoError(t, err) {
+			assert.Equal(t, ``+
+				`<ol><li>1</li><li>2</li></ol>`+
+				`<blockquote class="notion-color-gray">q</blockquote>`+
+				`<details class="notion-toggle"><summary>t</summary><hr></details>`+
+				`<table class="notion-table"><thead><tr><th scope="col"></th><th scope="col">A</th></tr></thead>`+
+				`<tbody><tr><th scope="row">x</th><td>1</td></tr></tbody></table>`,
+				string(data))
+		}
+	})
+}
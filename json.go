@@ -20,3 +20,22 @@ func omitFields(data []byte, visibility map[string]bool) ([]byte, error) {
 	}
 	return json.Marshal(t)
 }
+
+// getField は data のオブジェクトの key フィールドの値を返します。フィールドが存在しない場合は nil を返します
+func getField(data []byte, key string) (json.RawMessage, error) {
+	t := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &t); err != nil {
+		return nil, errors.Wrap(err, "getField")
+	}
+	return t[key], nil
+}
+
+// setField は data のオブジェクトの key フィールドに value を設定します
+func setField(data []byte, key string, value json.RawMessage) ([]byte, error) {
+	t := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &t); err != nil {
+		return nil, errors.Wrap(err, "setField")
+	}
+	t[key] = value
+	return json.Marshal(t)
+}
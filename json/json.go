@@ -5,9 +5,14 @@
 package json
 
 import (
+	stdjson "encoding/json"
+
 	jsoniter "github.com/json-iterator/go"
 )
 
+// RawMessage は encoding/json の RawMessage です
+type RawMessage = stdjson.RawMessage
+
 var json jsoniter.API
 
 func init() {
@@ -0,0 +1,35 @@
+package notion
+
+import (
+	"testing"
+
+	"github.com/psyark/notion/json"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnknownPayload(t *testing.T) {
+	t.Run("unknown type", func(t *testing.T) {
+		data := `{"object":"block","id":"00000000-0000-0000-0000-000000000001","type":"ai_block","ai_block":{"prompt":"x","nested":[1,2]},"has_children":false}`
+
+		var block Block
+		if !assert.NoError(t, json.Unmarshal([]byte(data), &block)) {
+			return
+		}
+		assert.JSONEq(t, `{"prompt":"x","nested":[1,2]}`, string(block.UnknownPayload))
+
+		got, err := json.Marshal(block)
+		if assert.NoError(t, err) {
+			assert.Contains(t, string(got), `"type":"ai_block"`)
+			assert.Contains(t, string(got), `"ai_block":{"prompt":"x","nested":[1,2]}`)
+		}
+	})
+
+	t.Run("known type", func(t *testing.T) {
+		var block Block
+		block.UnknownPayload = json.RawMessage(`{}`)
+		if assert.NoError(t, json.Unmarshal([]byte(`{"type":"quote","quote":{"rich_text":[],"color":"default"}}`), &block)) {
+			assert.Nil(t, block.UnknownPayload)
+			assert.NotNil(t, block.Quote)
+		}
+	})
+}
@@ -0,0 +1,179 @@
+package markdown
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/psyark/notion"
+)
+
+const (
+	maxChildren = 100  // 1つの配列で追加できるブロックの最大数
+	maxBlocks   = 1000 // 1回のリクエストで追加できるブロックの合計の最大数
+)
+
+// Append は blocks を parentId のブロック（またはページ）の子として追加し、追加された最上位のブロックを返します
+//
+// 1回のリクエストで追加できるブロックの数（1つの配列につき100、合計1000）と入れ子の深さ（2）の制限を超える場合は、
+// 複数のリクエストに分割し、深い子ブロックは親ブロックの作成後に追加します。
+// 列リストの場合は列を残し、列の子ブロックを列の作成後に追加します。
+// ページを作成する場合は、Children を指定せずに CreatePage を呼び出した後、作成されたページに Append してください。
+func Append(ctx context.Context, client *notion.Client, parentId uuid.UUID, blocks []notion.Block, options ...notion.CallOption) ([]notion.Block, error) {
+	created := []notion.Block{}
+	for len(blocks) != 0 {
+		payload := []notion.Block{}
+		deferred := [][]notion.Block{}
+		columns := [][][]notion.Block{}
+		total := 0
+		for _, block := range blocks {
+			p, d, c := prepare(block)
+			size := 1 + len(childrenOf(p))
+			if len(payload) == maxChildren || total+size > maxBlocks {
+				break
+			}
+			payload = append(payload, p)
+			deferred = append(deferred, d)
+			columns = append(columns, c)
+			total += size
+		}
+		blocks = blocks[len(payload):]
+
+		params := notion.AppendBlockChildrenParams{}
+		params.Children(payload)
+		result, err := client.AppendBlockChildren(ctx, parentId, params, options...)
+		if err != nil {
+			return nil, err
+		}
+
+		for i, block := range result.Results {
+			if len(deferred[i]) != 0 {
+				if _, err := Append(ctx, client, block.Id, deferred[i], options...); err != nil {
+					return nil, err
+				}
+			}
+			if len(columns[i]) != 0 {
+				if err := appendToColumns(ctx, client, block.Id, columns[i], options...); err != nil {
+					return nil, err
+				}
+			}
+		}
+		created = append(created, result.Results...)
+	}
+	return created, nil
+}
+
+// prepare は、block を1回のリクエストで追加できる形にしたコピーと、block の作成後に追加する子ブロックを返します
+// 列リストの場合は列を残し、各列から取り除いた子ブロックを columns として返します
+func prepare(block notion.Block) (payload notion.Block, deferred []notion.Block, columns [][]notion.Block) {
+	if fitsInRequest(childrenOf(block)) {
+		return block, nil, nil
+	}
+	if block.ColumnList != nil {
+		v := *block.ColumnList
+		v.Children = make([]notion.Block, len(block.ColumnList.Children))
+		columns = make([][]notion.Block, len(block.ColumnList.Children))
+		for i, column := range block.ColumnList.Children {
+			v.Children[i], columns[i] = detachChildren(column)
+		}
+		block.ColumnList = &v
+		return block, nil, columns
+	}
+	payload, deferred = detachChildren(block)
+	return payload, deferred, nil
+}
+
+// appendToColumns は、列リスト columnListId の各列に、columns のうち対応する子ブロックを追加します
+func appendToColumns(ctx context.Context, client *notion.Client, columnListId uuid.UUID, columns [][]notion.Block, options ...notion.CallOption) error {
+	i := 0
+	for column, err := range client.RetrieveBlockChildrenAll(ctx, columnListId, notion.RetrieveBlockChildrenParams{}, options...) {
+		if err != nil {
+			return err
+		}
+		if i < len(columns) && len(columns[i]) != 0 {
+			if _, err := Append(ctx, client, column.Id, columns[i], options...); err != nil {
+				return err
+			}
+		}
+		i++
+	}
+	return nil
+}
+
+// fitsInRequest は、子ブロック children を親ブロックと同じリクエストで追加できるかを返します
+func fitsInRequest(children []notion.Block) bool {
+	if len(children) > maxChildren {
+		return false
+	}
+	for _, child := range children {
+		if len(childrenOf(child)) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// childrenOf は block の Children フィールドの値を返します
+func childrenOf(block notion.Block) []notion.Block {
+	if children := block.ChildrenField(); children != nil {
+		return *children
+	}
+	return nil
+}
+
+// detachChildren は、子ブロックを取り除いた block のコピーと、取り除いた子ブロックを返します
+func detachChildren(block notion.Block) (notion.Block, []notion.Block) {
+	switch {
+	case block.Paragraph != nil:
+		v := *block.Paragraph
+		block.Paragraph = &v
+	case block.BulletedListItem != nil:
+		v := *block.BulletedListItem
+		block.BulletedListItem = &v
+	case block.ToDo != nil:
+		v := *block.ToDo
+		block.ToDo = &v
+	case block.Callout != nil:
+		v := *block.Callout
+		block.Callout = &v
+	case block.Heading1 != nil:
+		v := *block.Heading1
+		block.Heading1 = &v
+	case block.Heading2 != nil:
+		v := *block.Heading2
+		block.Heading2 = &v
+	case block.Heading3 != nil:
+		v := *block.Heading3
+		block.Heading3 = &v
+	case block.SyncedBlock != nil:
+		v := *block.SyncedBlock
+		block.SyncedBlock = &v
+	case block.NumberedListItem != nil:
+		v := *block.NumberedListItem
+		block.NumberedListItem = &v
+	case block.Quote != nil:
+		v := *block.Quote
+		block.Quote = &v
+	case block.Toggle != nil:
+		v := *block.Toggle
+		block.Toggle = &v
+	case block.Template != nil:
+		v := *block.Template
+		block.Template = &v
+	case block.Column != nil:
+		v := *block.Column
+		block.Column = &v
+	case block.Table != nil:
+		// 表は少なくとも1行を含めて作成する必要があるため、1回のリクエストに収まる行は残します
+		v := *block.Table
+		rows := v.Children
+		v.Children = rows[:min(len(rows), maxChildren)]
+		block.Table = &v
+		return block, rows[len(v.Children):]
+	default:
+		return block, nil
+	}
+	children := block.ChildrenField()
+	detached := *children
+	*children = nil
+	return block, detached
+}
@@ -0,0 +1,301 @@
+// Package markdown は、Notionのブロックと Markdown（CommonMark・GitHub Flavored Markdown）を相互に変換します
+package markdown
+
+import (
+	"context"
+	"html"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/psyark/notion"
+)
+
+// Export は blockId のページ（またはブロック）の子ブロックを再帰的に取得し、Markdown に変換します
+// 子ページと子データベースの中身は取得せず、リンクとして出力します
+func Export(ctx context.Context, client *notion.Client, blockId uuid.UUID, options ...notion.CallOption) (string, error) {
+	r := &renderer{children: map[uuid.UUID][]notion.Block{}}
+	blocks, err := r.fetch(ctx, client, blockId, options)
+	if err != nil {
+		return "", err
+	}
+	return r.render(blocks), nil
+}
+
+// Render は blocks を Markdown に変換します
+// 子ブロックは各ブロックの Children フィールドから取得されます
+func Render(blocks []notion.Block) string {
+	r := &renderer{}
+	return r.render(blocks)
+}
+
+// fetch は id のブロックの子ブロックを取得し、子孫ブロックを r.children に格納します
+func (r *renderer) fetch(ctx context.Context, client *notion.Client, id uuid.UUID, options []notion.CallOption) ([]notion.Block, error) {
+	blocks := []notion.Block{}
+	for block, err := range client.RetrieveBlockChildrenAll(ctx, id, notion.RetrieveBlockChildrenParams{}, options...) {
+		if err != nil {
+			return nil, err
+		}
+		if block.HasChildren && block.ChildPage == nil && block.ChildDatabase == nil {
+			children, err := r.fetch(ctx, client, block.Id, options)
+			if err != nil {
+				return nil, err
+			}
+			r.children[block.Id] = children
+		}
+		blocks = append(blocks, block)
+	}
+	return blocks, nil
+}
+
+// renderer はブロックを Markdown に変換します
+type renderer struct {
+	children map[uuid.UUID][]notion.Block // Export で取得した子ブロック
+}
+
+// childrenOf は block の子ブロックを返します
+func (r *renderer) childrenOf(block notion.Block) []notion.Block {
+	if children, ok := r.children[block.Id]; ok && block.Id != uuid.Nil {
+		return children
+	}
+	return childrenOf(block)
+}
+
+func (r *renderer) render(blocks []notion.Block) string {
+	b := &strings.Builder{}
+	prevList := ""
+	for _, block := range blocks {
+		md, list := r.renderBlock(block)
+		if md == "" {
+			continue
+		}
+		if b.Len() != 0 {
+			// 同じ種類のリスト項目は空行を挟まずに続けます
+			if list != "" && list == prevList {
+				b.WriteString("\n")
+			} else {
+				b.WriteString("\n\n")
+			}
+		}
+		b.WriteString(md)
+		prevList = list
+	}
+	return b.String()
+}
+
+// renderBlock は1つのブロックを変換し、リスト項目の場合はリストの種類と共に返します
+func (r *renderer) renderBlock(block notion.Block) (string, string) {
+	children := r.render(r.childrenOf(block))
+	withChildren := func(md string) string {
+		if children == "" {
+			return md
+		}
+		return md + "\n\n" + children
+	}
+
+	switch {
+	case block.Paragraph != nil:
+		return withChildren(renderRichText(block.Paragraph.RichText)), ""
+	case block.Heading1 != nil:
+		return withChildren("# " + renderRichText(block.Heading1.RichText)), ""
+	case block.Heading2 != nil:
+		return withChildren("## " + renderRichText(block.Heading2.RichText)), ""
+	case block.Heading3 != nil:
+		return withChildren("### " + renderRichText(block.Heading3.RichText)), ""
+	case block.BulletedListItem != nil:
+		return listItem("- ", renderRichText(block.BulletedListItem.RichText), children), "-"
+	case block.NumberedListItem != nil:
+		return listItem("1. ", renderRichText(block.NumberedListItem.RichText), children), "1."
+	case block.ToDo != nil:
+		mark := "- [ ] "
+		if block.ToDo.Checked != nil && *block.ToDo.Checked {
+			mark = "- [x] "
+		}
+		return listItem(mark, renderRichText(block.ToDo.RichText), children), "-"
+	case block.Code != nil:
+		return codeFence(block.Code.RichText.String(), codeLanguage(block.Code.Language)), ""
+	case block.Quote != nil:
+		return quote(withChildren(renderRichText(block.Quote.RichText))), ""
+	case block.Toggle != nil:
+		return toggle(block.Toggle.RichText.String(), children), ""
+	case block.Table != nil:
+		return r.table(block.Table, r.childrenOf(block)), ""
+	case block.Callout != nil:
+		text := renderRichText(block.Callout.RichText)
+		if emoji, ok := block.Callout.Icon.(*notion.Emoji); ok {
+			text = emoji.Emoji + " " + text
+		}
+		return quote(withChildren(text)), ""
+	case block.Equation != nil:
+		return "$$\n" + block.Equation.Expression + "\n$$", ""
+	case block.Divider != nil:
+		return "---", ""
+	case block.Image != nil:
+		return "![" + escapeText(block.Image.Caption.String()) + "](" + linkDestination(fileURL(block.Image)) + ")", ""
+	case block.File != nil:
+		return fileLink(block.File.Name, block.File.Caption, fileURL(block.File)), ""
+	case block.Pdf != nil:
+		return fileLink("", block.Pdf.Caption, fileURL(&notion.File{File: block.Pdf.File, External: block.Pdf.External})), ""
+	case block.Video != nil:
+		return fileLink("", block.Video.Caption, fileURL(block.Video)), ""
+	case block.Audio != nil:
+		return fileLink("", block.Audio.Caption, fileURL(block.Audio)), ""
+	case block.Bookmark != nil:
+		return fileLink("", block.Bookmark.Caption, block.Bookmark.Url), ""
+	case block.Embed != nil:
+		return fileLink("", nil, block.Embed.Url), ""
+	case block.LinkPreview != nil:
+		return fileLink("", nil, block.LinkPreview.Url), ""
+	case block.LinkToPage != nil:
+		id := block.LinkToPage.PageId
+		if block.LinkToPage.Type == "database_id" {
+			id = block.LinkToPage.DatabaseId
+		}
+		return fileLink("", nil, pageURL(id)), ""
+	case block.ChildPage != nil:
+		return "[" + escapeText(block.ChildPage.Title) + "](" + pageURL(block.Id) + ")", ""
+	case block.ChildDatabase != nil:
+		return "[" + escapeText(block.ChildDatabase.Title) + "](" + pageURL(block.Id) + ")", ""
+	case block.Template != nil, block.TableOfContents != nil, block.TableRow != nil:
+		// テンプレートボタンと目次は出力しません。表の行は table の一部として出力されます
+		return "", ""
+	default:
+		// column_list、column、synced_block などは子ブロックのみを出力します
+		return children, ""
+	}
+}
+
+// listItem はリスト項目を作成します。継続行と子ブロックはマーカーの幅だけ字下げされます
+// ただしタスクリストのマーカー（"- [ ] "）の場合は "- " の幅だけ字下げします
+func listItem(marker string, text string, children string) string {
+	prefix := strings.Repeat(" ", len(strings.SplitAfter(marker, " ")[0]))
+	md := marker + indent(text, prefix)
+	switch {
+	case children == "":
+	case strings.HasPrefix(children, "- ") || strings.HasPrefix(children, "1. "):
+		md += "\n" + prefix + indent(children, prefix)
+	default:
+		// 入れ子のリスト以外は、項目のテキストの継続行と解釈されないよう空行を挟みます
+		md += "\n\n" + prefix + indent(children, prefix)
+	}
+	return md
+}
+
+// toggle はトグルを details 要素として出力します
+func toggle(summary string, children string) string {
+	md := "<details>\n<summary>" + html.EscapeString(summary) + "</summary>\n\n"
+	if children != "" {
+		md += children + "\n\n"
+	}
+	return md + "</details>"
+}
+
+// table は表を GitHub Flavored Markdown の表として出力します。rows は表の子ブロック（table_row）です
+// 見出し行の無い表は、空の見出し行を持つ表として出力します
+func (r *renderer) table(table *notion.BlockTable, rows []notion.Block) string {
+	lines := []string{}
+	row := func(cells []notion.RichTextArray) {
+		texts := make([]string, table.TableWidth)
+		for i, cell := range cells[:min(len(cells), table.TableWidth)] {
+			texts[i] = tableCell(renderRichText(cell))
+		}
+		lines = append(lines, "| "+strings.Join(texts, " | ")+" |")
+	}
+
+	if table.HasColumnHeader == nil || !*table.HasColumnHeader {
+		row(nil)
+		lines = append(lines, "|"+strings.Repeat(" --- |", table.TableWidth))
+	}
+	for _, block := range rows {
+		if block.TableRow == nil {
+			continue
+		}
+		row(block.TableRow.Cells)
+		if len(lines) == 1 {
+			lines = append(lines, "|"+strings.Repeat(" --- |", table.TableWidth))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// tableCell は、表のセルで区切りと解釈される "|" をエスケープし、改行を <br> に置き換えます
+// escapeText によってエスケープされていない "|" はコードスパンなどに含まれるものです
+func tableCell(md string) string {
+	md = strings.ReplaceAll(md, "\\\n", "<br>")
+	b := &strings.Builder{}
+	for i, c := range md {
+		if c == '|' && (i == 0 || md[i-1] != '\\') {
+			b.WriteString(`\|`)
+		} else {
+			b.WriteRune(c)
+		}
+	}
+	return b.String()
+}
+
+// indent は md の2行目以降の空でない行を prefix で字下げします
+func indent(md string, prefix string) string {
+	lines := strings.Split(md, "\n")
+	for i := 1; i < len(lines); i++ {
+		if lines[i] != "" {
+			lines[i] = prefix + lines[i]
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// quote は md を引用ブロックにします
+func quote(md string) string {
+	lines := strings.Split(md, "\n")
+	for i, line := range lines {
+		if line == "" {
+			lines[i] = ">"
+		} else {
+			lines[i] = "> " + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// codeFence は code をフェンスで囲みます。フェンスには code に含まれない長さのバッククォートを使用します
+func codeFence(code string, language string) string {
+	fence := "```"
+	for strings.Contains(code, fence) {
+		fence += "`"
+	}
+	return fence + language + "\n" + code + "\n" + fence
+}
+
+// codeLanguage は BlockCode.Language をフェンスの情報文字列に変換します
+func codeLanguage(language string) string {
+	if language == "plain text" {
+		return ""
+	}
+	return strings.ReplaceAll(language, " ", "-")
+}
+
+func fileURL(file *notion.File) string {
+	switch {
+	case file.File != nil:
+		return file.File.Url
+	case file.External != nil:
+		return file.External.Url
+	}
+	return ""
+}
+
+// fileLink はファイルやブックマークへのリンクを作成します
+// リンクのテキストはキャプション、名前、URLの順に空でないものを使用します
+func fileLink(name string, caption notion.RichTextArray, url string) string {
+	text := renderRichText(caption)
+	if text == "" {
+		text = escapeText(name)
+	}
+	if text == "" {
+		text = escapeText(url)
+	}
+	return "[" + text + "](" + linkDestination(url) + ")"
+}
+
+func pageURL(id uuid.UUID) string {
+	return "https://www.notion.so/" + strings.ReplaceAll(id.String(), "-", "")
+}
@@ -0,0 +1,98 @@
+package markdown_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/psyark/notion"
+	"github.com/psyark/notion/markdown"
+	"github.com/psyark/notion/notiontest"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRender(t *testing.T) {
+	text := notion.NewRichTextArray
+	link := "https://example.com/a b"
+
+	blocks := []notion.Block{
+		{Heading1: &notion.BlockHeading{RichText: text("Title")}},
+		{Paragraph: &notion.BlockParagraph{RichText: notion.RichTextArray{
+			notion.NewRichText("plain "),
+			notion.NewRichText("bold ", notion.WithAnnotation(notion.Annotations{Bold: true})),
+			notion.NewRichText("italic ", notion.WithAnnotation(notion.Annotations{Italic: true})),
+			notion.NewRichText("both ", notion.WithAnnotation(notion.Annotations{Bold: true, Italic: true})),
+			notion.NewRichText("code`", notion.WithAnnotation(notion.Annotations{Code: true})),
+			{Text: &notion.RichTextText{Content: "link"}, PlainText: "link", Href: &link},
+			{Equation: &notion.RichTextEquation{Expression: "x^2"}, PlainText: "x^2"},
+			notion.NewRichText(" *not emphasis*\n# not heading"),
+		}}},
+		{BulletedListItem: &notion.BlockBulletedListItem{RichText: text("one"), Children: []notion.Block{
+			{ToDo: &notion.BlockToDo{RichText: text("done"), Checked: lo.ToPtr(true)}},
+			{ToDo: &notion.BlockToDo{RichText: text("todo")}},
+		}}},
+		{BulletedListItem: &notion.BlockBulletedListItem{RichText: text("two")}},
+		{Code: &notion.BlockCode{RichText: text("fmt.Println(\"```\")"), Language: "go"}},
+		{Callout: &notion.BlockCallout{RichText: text("note"), Icon: &notion.Emoji{Emoji: "💡"}, Children: []notion.Block{
+			{Paragraph: &notion.BlockParagraph{RichText: text("inside")}},
+		}}},
+		{Equation: &notion.BlockEquation{Expression: `\int x dx`}},
+		{Divider: &struct{}{}},
+		{Image: &notion.File{External: &notion.FileExternal{Url: "https://example.com/i.png"}, Caption: text("image")}},
+		{Bookmark: &notion.BlockBookmark{Url: "https://example.com"}},
+	}
+
+	assert.Equal(t, "# Title\n\n"+
+		"plain **bold** *italic* ***both*** `` code` ``[link](<https://example.com/a b>)$x^2$ \\*not emphasis\\*\\\n\\# not heading\n\n"+
+		"- one\n  - [x] done\n  - [ ] todo\n- two\n\n"+
+		"````go\nfmt.Println(\"```\")\n````\n\n"+
+		"> 💡 note\n>\n> inside\n\n"+
+		"$$\n\\int x dx\n$$\n\n"+
+		"---\n\n"+
+		"![image](https://example.com/i.png)\n\n"+
+		"[https://example.com](https://example.com)", markdown.Render(blocks))
+
+	t.Run("block types", func(t *testing.T) {
+		blocks := []notion.Block{
+			{NumberedListItem: &notion.BlockNumberedListItem{RichText: text("first"), Children: []notion.Block{
+				{Paragraph: &notion.BlockParagraph{RichText: text("nested")}},
+			}}},
+			{NumberedListItem: &notion.BlockNumberedListItem{RichText: text("second")}},
+			{Quote: &notion.BlockQuote{RichText: text("quoted")}},
+			{Toggle: &notion.BlockToggle{RichText: text("<more>"), Children: []notion.Block{
+				{Paragraph: &notion.BlockParagraph{RichText: text("hidden")}},
+			}}},
+			{Table: &notion.BlockTable{TableWidth: 2, Children: []notion.Block{
+				{TableRow: &notion.BlockTableRow{Cells: []notion.RichTextArray{text("a|b"), text("line\nbreak")}}},
+			}}},
+			{TableOfContents: &notion.BlockTableOfContents{}},
+		}
+		assert.Equal(t, "1. first\n\n   nested\n1. second\n\n"+
+			"> quoted\n\n"+
+			"<details>\n<summary>&lt;more&gt;</summary>\n\nhidden\n\n</details>\n\n"+
+			"|  |  |\n| --- | --- |\n| a\\|b | line<br>break |", markdown.Render(blocks))
+	})
+}
+
+func TestExport(t *testing.T) {
+	ctx := context.Background()
+	server := notiontest.NewServer()
+	defer server.Close()
+
+	client := server.NewClient()
+	root := server.AddPage("Root")
+
+	params := notion.AppendBlockChildrenParams{}
+	params.Children([]notion.Block{
+		{Heading2: &notion.BlockHeading{RichText: notion.NewRichTextArray("Section")}},
+		{BulletedListItem: &notion.BlockBulletedListItem{RichText: notion.NewRichTextArray("parent"), Children: []notion.Block{
+			{BulletedListItem: &notion.BlockBulletedListItem{RichText: notion.NewRichTextArray("child")}},
+		}}},
+	})
+	lo.Must(client.AppendBlockChildren(ctx, root, params))
+
+	md, err := markdown.Export(ctx, client, root)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "## Section\n\n- parent\n  - child", md)
+	}
+}
@@ -0,0 +1,420 @@
+package markdown
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/psyark/notion"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	extast "github.com/yuin/goldmark/extension/ast"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+const (
+	maxTextLength = 2000 // 1つのリッチテキストの最大文字数（UTF-16）
+	maxRichTexts  = 100  // 1つのリッチテキスト配列の最大要素数
+)
+
+// Import は Markdown を解釈し、CreatePageParams.Children や AppendBlockChildrenParams.Children に渡せるブロックに変換します
+//
+// 強調・コード・リンクは Annotations と RichTextText.Link に、フェンスで囲まれたコードは BlockCode に、
+// 引用は BlockQuote に、番号付きリストは BlockNumberedListItem に、タスクリストは BlockToDo に、
+// 表は BlockTable に、入れ子のリストは Children に変換されます。
+// 2000文字を超えるテキストは複数のリッチテキストに分割されます。
+// リッチテキストが100個を超える段落・見出しは複数のブロックに分割され、リスト項目では超えた分が子ブロックの段落となります。
+// 1回のリクエストで送信できるブロックの数と入れ子の深さには制限があるため、
+// 結果をそのまま送信せずに Append を使用してください。
+func Import(source []byte) []notion.Block {
+	md := goldmark.New(goldmark.WithExtensions(extension.Strikethrough, extension.TaskList, extension.Linkify, extension.Table))
+	doc := md.Parser().Parse(text.NewReader(source))
+	c := &converter{source: source}
+	return c.blocks(doc)
+}
+
+// converter は goldmark の構文木をブロックに変換します
+type converter struct {
+	source []byte
+}
+
+// blocks は parent の子ノードをブロックに変換します
+func (c *converter) blocks(parent ast.Node) []notion.Block {
+	blocks := []notion.Block{}
+	for n := parent.FirstChild(); n != nil; n = n.NextSibling() {
+		blocks = append(blocks, c.block(n)...)
+	}
+	return blocks
+}
+
+func (c *converter) block(n ast.Node) []notion.Block {
+	switch n := n.(type) {
+	case *ast.Heading:
+		richText, overflow := splitOverflow(c.richText(n))
+		heading := &notion.BlockHeading{RichText: richText}
+		switch n.Level {
+		case 1:
+			return append([]notion.Block{{Heading1: heading}}, overflow...)
+		case 2:
+			return append([]notion.Block{{Heading2: heading}}, overflow...)
+		default:
+			return append([]notion.Block{{Heading3: heading}}, overflow...)
+		}
+
+	case *ast.Paragraph, *ast.TextBlock:
+		if image, ok := onlyImage(n); ok {
+			return []notion.Block{{Image: &notion.File{
+				External: &notion.FileExternal{Url: string(image.Destination)},
+				Caption:  c.richText(image),
+			}}}
+		}
+		return paragraphs(c.richText(n))
+
+	case *ast.ThematicBreak:
+		return []notion.Block{{Divider: &struct{}{}}}
+
+	case *ast.FencedCodeBlock:
+		return []notion.Block{{Code: &notion.BlockCode{
+			RichText: splitText(notion.RichText{Text: &notion.RichTextText{Content: c.lines(n)}}),
+			Language: codeLanguageOf(string(n.Language(c.source))),
+		}}}
+
+	case *ast.CodeBlock:
+		return []notion.Block{{Code: &notion.BlockCode{
+			RichText: splitText(notion.RichText{Text: &notion.RichTextText{Content: c.lines(n)}}),
+			Language: "plain text",
+		}}}
+
+	case *ast.HTMLBlock:
+		content := c.lines(n)
+		if n.HasClosure() {
+			content += string(n.ClosureLine.Value(c.source))
+		}
+		return []notion.Block{{Paragraph: &notion.BlockParagraph{RichText: splitText(notion.RichText{Text: &notion.RichTextText{Content: strings.TrimRight(content, "\n")}})}}}
+
+	case *ast.Blockquote:
+		children := c.blocks(n)
+		quote := &notion.BlockQuote{RichText: notion.RichTextArray{}}
+		if len(children) != 0 && children[0].Paragraph != nil {
+			quote.RichText = children[0].Paragraph.RichText
+			children = children[1:]
+		}
+		quote.Children = children
+		return []notion.Block{{Quote: quote}}
+
+	case *extast.Table:
+		return []notion.Block{c.table(n)}
+
+	case *ast.List:
+		blocks := []notion.Block{}
+		for item := n.FirstChild(); item != nil; item = item.NextSibling() {
+			blocks = append(blocks, c.listItem(item, n.IsOrdered()))
+		}
+		return blocks
+
+	default:
+		return c.blocks(n)
+	}
+}
+
+// listItem はリスト項目をブロックに変換します。最初の段落が項目のテキストとなり、残りは子ブロックとなります
+func (c *converter) listItem(item ast.Node, ordered bool) notion.Block {
+	var richText notion.RichTextArray
+	var checked *bool
+
+	first := item.FirstChild()
+	if first != nil && (first.Kind() == ast.KindParagraph || first.Kind() == ast.KindTextBlock) {
+		if box, ok := first.FirstChild().(*extast.TaskCheckBox); ok {
+			checked = &box.IsChecked
+		}
+		richText = c.richText(first)
+		first = first.NextSibling()
+	}
+	if richText == nil {
+		richText = notion.RichTextArray{}
+	}
+
+	// 項目のテキストに収まらないリッチテキストは、最初の子ブロックとなる段落に移します
+	richText, children := splitOverflow(richText)
+	for n := first; n != nil; n = n.NextSibling() {
+		children = append(children, c.block(n)...)
+	}
+
+	if checked != nil {
+		return notion.Block{ToDo: &notion.BlockToDo{RichText: richText, Checked: checked, Children: children}}
+	}
+	if ordered {
+		return notion.Block{NumberedListItem: &notion.BlockNumberedListItem{RichText: richText, Children: children}}
+	}
+	return notion.Block{BulletedListItem: &notion.BlockBulletedListItem{RichText: richText, Children: children}}
+}
+
+// table は表をブロックに変換します。Markdown の表の最初の行は常に見出し行です
+func (c *converter) table(n *extast.Table) notion.Block {
+	hasColumnHeader := true
+	table := &notion.BlockTable{TableWidth: len(n.Alignments), HasColumnHeader: &hasColumnHeader, Children: []notion.Block{}}
+	for row := n.FirstChild(); row != nil; row = row.NextSibling() {
+		cells := []notion.RichTextArray{}
+		for cell := row.FirstChild(); cell != nil; cell = cell.NextSibling() {
+			cells = append(cells, cellText(c.richText(cell)))
+		}
+		for len(cells) < table.TableWidth {
+			cells = append(cells, notion.RichTextArray{})
+		}
+		table.Children = append(table.Children, notion.Block{TableRow: &notion.BlockTableRow{Cells: cells}})
+	}
+	return notion.Block{Table: table}
+}
+
+// lines はコードブロックなどの行を連結し、末尾の改行を除いて返します
+func (c *converter) lines(n ast.Node) string {
+	b := &bytes.Buffer{}
+	lines := n.Lines()
+	for i := 0; i < lines.Len(); i++ {
+		segment := lines.At(i)
+		b.Write(segment.Value(c.source))
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// onlyImage は段落が1つの画像だけを含む場合にその画像を返します
+func onlyImage(n ast.Node) (*ast.Image, bool) {
+	image, ok := n.FirstChild().(*ast.Image)
+	return image, ok && n.ChildCount() == 1
+}
+
+// span はインライン要素を変換する際の書式の状態です
+type span struct {
+	annotations notion.Annotations
+	link        string
+}
+
+// richText は n のインライン要素をリッチテキストに変換します
+func (c *converter) richText(n ast.Node) notion.RichTextArray {
+	rta := notion.RichTextArray{}
+	c.inline(n, span{annotations: notion.Annotations{Color: "default"}}, &rta)
+
+	// 書式の等しい隣接するテキストを結合し、長すぎるテキストを分割します
+	merged := notion.RichTextArray{}
+	for _, rt := range rta {
+		if last := len(merged) - 1; last >= 0 && sameStyle(merged[last], rt) {
+			merged[last].Text.Content += rt.Text.Content
+			merged[last].PlainText += rt.PlainText
+			continue
+		}
+		merged = append(merged, rt)
+	}
+	result := notion.RichTextArray{}
+	for _, rt := range merged {
+		result = append(result, splitText(rt)...)
+	}
+	return result
+}
+
+func (c *converter) inline(parent ast.Node, s span, rta *notion.RichTextArray) {
+	add := func(content string, s span) {
+		if content == "" {
+			return
+		}
+		rt := notion.RichText{Text: &notion.RichTextText{Content: content}, PlainText: content, Annotations: s.annotations}
+		if s.link != "" {
+			rt.Text.Link = &notion.URLReference{URL: s.link}
+			rt.Href = &s.link
+		}
+		*rta = append(*rta, rt)
+	}
+
+	for n := parent.FirstChild(); n != nil; n = n.NextSibling() {
+		switch n := n.(type) {
+		case *ast.Text:
+			value := n.Segment.Value(c.source)
+			if !n.IsRaw() {
+				value = util.UnescapePunctuations(value)
+				value = util.ResolveNumericReferences(value)
+				value = util.ResolveEntityNames(value)
+			}
+			content := string(value)
+			switch {
+			case n.HardLineBreak():
+				content += "\n"
+			case n.SoftLineBreak():
+				content += " "
+			}
+			add(content, s)
+
+		case *ast.String:
+			add(string(n.Value), s)
+
+		case *ast.CodeSpan:
+			code := s
+			code.annotations.Code = true
+			b := &strings.Builder{}
+			for t := n.FirstChild(); t != nil; t = t.NextSibling() {
+				if t, ok := t.(*ast.Text); ok {
+					b.Write(t.Segment.Value(c.source))
+				}
+			}
+			add(b.String(), code)
+
+		case *ast.Emphasis:
+			emphasis := s
+			if n.Level >= 2 {
+				emphasis.annotations.Bold = true
+			} else {
+				emphasis.annotations.Italic = true
+			}
+			c.inline(n, emphasis, rta)
+
+		case *extast.Strikethrough:
+			strike := s
+			strike.annotations.Strikethrough = true
+			c.inline(n, strike, rta)
+
+		case *ast.Link:
+			link := s
+			link.link = string(n.Destination)
+			c.inline(n, link, rta)
+
+		case *ast.AutoLink:
+			link := s
+			link.link = string(n.URL(c.source))
+			if n.AutoLinkType == ast.AutoLinkEmail && !strings.HasPrefix(link.link, "mailto:") {
+				link.link = "mailto:" + link.link
+			}
+			add(string(n.Label(c.source)), link)
+
+		case *ast.Image:
+			// 段落中の画像は代替テキストによるリンクとして表現します
+			link := s
+			link.link = string(n.Destination)
+			before := len(*rta)
+			c.inline(n, link, rta)
+			if len(*rta) == before {
+				add(link.link, link)
+			}
+
+		case *ast.RawHTML:
+			html := &strings.Builder{}
+			for i := 0; i < n.Segments.Len(); i++ {
+				segment := n.Segments.At(i)
+				html.Write(segment.Value(c.source))
+			}
+			// Export が出力する下線の記法に対応します
+			switch strings.ToLower(html.String()) {
+			case "<u>":
+				s.annotations.Underline = true
+			case "</u>":
+				s.annotations.Underline = false
+			case "<br>", "<br/>", "<br />":
+				add("\n", s)
+			default:
+				add(html.String(), s)
+			}
+
+		case *extast.TaskCheckBox:
+			// チェックボックスは listItem で処理されます
+
+		default:
+			c.inline(n, s, rta)
+		}
+	}
+}
+
+func sameStyle(a, b notion.RichText) bool {
+	return a.Text != nil && b.Text != nil && a.Annotations == b.Annotations && (a.Text.Link == nil) == (b.Text.Link == nil) && (a.Text.Link == nil || a.Text.Link.URL == b.Text.Link.URL)
+}
+
+// splitText は rt を、UTF-16で maxTextLength 文字以下のリッチテキストに分割します
+func splitText(rt notion.RichText) notion.RichTextArray {
+	units := utf16.Encode([]rune(rt.Text.Content))
+	if len(units) <= maxTextLength {
+		rt.PlainText = rt.Text.Content
+		return notion.RichTextArray{rt}
+	}
+
+	rta := notion.RichTextArray{}
+	for len(units) != 0 {
+		n := min(len(units), maxTextLength)
+		// サロゲートペアを分割しないようにします
+		if n < len(units) && utf16.IsSurrogate(rune(units[n-1])) && units[n-1] < 0xdc00 {
+			n--
+		}
+		part := rt
+		text := *rt.Text
+		text.Content = string(utf16.Decode(units[:n]))
+		part.Text = &text
+		part.PlainText = text.Content
+		rta = append(rta, part)
+		units = units[n:]
+	}
+	return rta
+}
+
+// chunkRichText は rta を、要素数が maxRichTexts 以下の配列に分割します
+func chunkRichText(rta notion.RichTextArray) []notion.RichTextArray {
+	if len(rta) <= maxRichTexts {
+		return []notion.RichTextArray{rta}
+	}
+	return slices.Collect(slices.Chunk(rta, maxRichTexts))
+}
+
+// paragraphs は rta を、要素数が maxRichTexts 以下の段落ブロックに分割します
+func paragraphs(rta notion.RichTextArray) []notion.Block {
+	blocks := []notion.Block{}
+	for _, chunk := range chunkRichText(rta) {
+		blocks = append(blocks, notion.Block{Paragraph: &notion.BlockParagraph{RichText: chunk}})
+	}
+	return blocks
+}
+
+// splitOverflow は rta の先頭の maxRichTexts 要素と、それに収まらない残りを段落ブロックとしたものを返します
+func splitOverflow(rta notion.RichTextArray) (notion.RichTextArray, []notion.Block) {
+	if len(rta) <= maxRichTexts {
+		return rta, []notion.Block{}
+	}
+	return rta[:maxRichTexts], paragraphs(rta[maxRichTexts:])
+}
+
+// cellText は表のセルのリッチテキストを返します
+// セルは分割できないため、要素数が maxRichTexts を超える場合は書式を除いたテキストとし、それでも収まらない部分は切り捨てます
+func cellText(rta notion.RichTextArray) notion.RichTextArray {
+	if len(rta) <= maxRichTexts {
+		return rta
+	}
+	plain := splitText(notion.RichText{Text: &notion.RichTextText{Content: rta.String()}, Annotations: notion.Annotations{Color: "default"}})
+	return plain[:min(len(plain), maxRichTexts)]
+}
+
+// codeLanguages はNotionが対応するコードブロックの言語です
+var codeLanguages = []string{
+	"abap", "arduino", "bash", "basic", "c", "clojure", "coffeescript", "c++", "c#", "css", "dart", "diff", "docker",
+	"elixir", "elm", "erlang", "flow", "fortran", "f#", "gherkin", "glsl", "go", "graphql", "groovy", "haskell", "html",
+	"java", "javascript", "json", "julia", "kotlin", "latex", "less", "lisp", "livescript", "lua", "makefile", "markdown",
+	"markup", "matlab", "mermaid", "nix", "objective-c", "ocaml", "pascal", "perl", "php", "plain text", "powershell",
+	"prolog", "protobuf", "python", "r", "reason", "ruby", "rust", "sass", "scala", "scheme", "scss", "shell", "sql",
+	"swift", "typescript", "vb.net", "verilog", "vhdl", "visual basic", "webassembly", "xml", "yaml", "java/c/c++/c#",
+}
+
+// codeLanguageAliases はフェンスの情報文字列でよく使われる言語名の別名です
+var codeLanguageAliases = map[string]string{
+	"cpp": "c++", "cs": "c#", "csharp": "c#", "fsharp": "f#", "dockerfile": "docker", "golang": "go",
+	"js": "javascript", "jsx": "javascript", "ts": "typescript", "tsx": "typescript", "py": "python", "rb": "ruby",
+	"rs": "rust", "kt": "kotlin", "sh": "shell", "zsh": "shell", "console": "shell", "ps1": "powershell", "pwsh": "powershell",
+	"yml": "yaml", "md": "markdown", "tex": "latex", "objc": "objective-c", "proto": "protobuf", "wasm": "webassembly",
+	"visual-basic": "visual basic", "vb": "visual basic", "text": "plain text", "txt": "plain text", "plaintext": "plain text",
+}
+
+// codeLanguageOf はフェンスの情報文字列を BlockCode.Language に変換します。対応しない言語は plain text となります
+func codeLanguageOf(info string) string {
+	language := strings.ToLower(info)
+	if alias, ok := codeLanguageAliases[language]; ok {
+		return alias
+	}
+	if slices.Contains(codeLanguages, language) {
+		return language
+	}
+	return "plain text"
+}
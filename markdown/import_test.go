@@ -0,0 +1,203 @@
+package markdown_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/psyark/notion"
+	"github.com/psyark/notion/markdown"
+	"github.com/psyark/notion/notiontest"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImport(t *testing.T) {
+	source := "# Title\n\n" +
+		"Some **bold** _italic_ ~~strike~~ `code` [link](https://example.com) \\*escaped\\* &amp;\n\n" +
+		"- one\n  - [x] nested done\n- [ ] todo\n\n" +
+		"```js\nconsole.log(1)\n```\n\n" +
+		"> quoted\n\n" +
+		"---\n\n" +
+		"![alt](https://example.com/i.png)\n\n" +
+		"1. first\n2. second\n\n" +
+		"| a | b |\n| --- | --- |\n| 1 | x\\|y |\n"
+
+	blocks := markdown.Import([]byte(source))
+	if !assert.Len(t, blocks, 11) {
+		return
+	}
+
+	assert.Equal(t, "Title", blocks[0].Heading1.RichText.String())
+
+	rta := blocks[1].Paragraph.RichText
+	assert.Equal(t, "Some bold italic strike code link *escaped* &", rta.String())
+	styled := lo.Filter(rta, func(rt notion.RichText, _ int) bool {
+		return rt.Annotations.Bold || rt.Annotations.Italic || rt.Annotations.Strikethrough || rt.Annotations.Code || rt.Href != nil
+	})
+	assert.Equal(t, []string{"bold", "italic", "strike", "code", "link"}, lo.Map(styled, func(rt notion.RichText, _ int) string { return rt.PlainText }))
+	assert.Equal(t, "https://example.com", styled[4].Text.Link.URL)
+
+	assert.Equal(t, "one", blocks[2].BulletedListItem.RichText.String())
+	if assert.Len(t, blocks[2].BulletedListItem.Children, 1) {
+		nested := blocks[2].BulletedListItem.Children[0].ToDo
+		assert.Equal(t, "nested done", nested.RichText.String())
+		assert.True(t, *nested.Checked)
+	}
+	assert.False(t, *blocks[3].ToDo.Checked)
+
+	assert.Equal(t, "javascript", blocks[4].Code.Language)
+	assert.Equal(t, "console.log(1)", blocks[4].Code.RichText.String())
+	assert.Equal(t, "quoted", blocks[5].Quote.RichText.String())
+	assert.NotNil(t, blocks[6].Divider)
+	assert.Equal(t, "https://example.com/i.png", blocks[7].Image.External.Url)
+	assert.Equal(t, "second", blocks[9].NumberedListItem.RichText.String())
+
+	if table := blocks[10].Table; assert.NotNil(t, table) {
+		assert.Equal(t, 2, table.TableWidth)
+		assert.True(t, *table.HasColumnHeader)
+		if assert.Len(t, table.Children, 2) {
+			assert.Equal(t, "x|y", table.Children[1].TableRow.Cells[1].String())
+		}
+	}
+
+	t.Run("round trip", func(t *testing.T) {
+		md := markdown.Render(blocks)
+		assert.Contains(t, md, "Some **bold** *italic* ~~strike~~ `code` [link](https://example.com) \\*escaped\\* &")
+		assert.Equal(t, md, markdown.Render(markdown.Import([]byte(md))))
+	})
+
+	t.Run("long text", func(t *testing.T) {
+		blocks := markdown.Import([]byte(strings.Repeat("あ", 4500)))
+		if assert.Len(t, blocks, 1) {
+			assert.Len(t, blocks[0].Paragraph.RichText, 3)
+			assert.Equal(t, 4500, len([]rune(blocks[0].Paragraph.RichText.String())))
+		}
+	})
+
+	t.Run("many spans", func(t *testing.T) {
+		spans := strings.TrimSpace(strings.Repeat("**a** b ", 60)) // 120個のリッチテキスト
+		text := strings.Repeat("a b ", 60)[:239]
+
+		blocks := markdown.Import([]byte("# " + spans + "\n\n- " + spans + "\n  - child\n\n| " + spans + " |\n| - |\n"))
+		if !assert.Len(t, blocks, 4) {
+			return
+		}
+
+		// 見出しに収まらない分は後続の段落となります
+		assert.Len(t, blocks[0].Heading1.RichText, 100)
+		assert.Len(t, blocks[1].Paragraph.RichText, 20)
+		assert.Equal(t, text, blocks[0].Heading1.RichText.String()+blocks[1].Paragraph.RichText.String())
+
+		// リスト項目に収まらない分は最初の子ブロックとなります
+		item := blocks[2].BulletedListItem
+		assert.Len(t, item.RichText, 100)
+		if assert.Len(t, item.Children, 2) {
+			assert.Equal(t, text, item.RichText.String()+item.Children[0].Paragraph.RichText.String())
+			assert.Equal(t, "child", item.Children[1].BulletedListItem.RichText.String())
+		}
+
+		// 表のセルは書式を除いたテキストとなります
+		cell := blocks[3].Table.Children[0].TableRow.Cells[0]
+		assert.Len(t, cell, 1)
+		assert.Equal(t, text, cell.String())
+	})
+}
+
+func TestAppend(t *testing.T) {
+	ctx := context.Background()
+	server := notiontest.NewServer()
+	defer server.Close()
+
+	client := server.NewClient()
+	root := server.AddPage("Root")
+
+	source := &strings.Builder{}
+	source.WriteString("- level 1\n  - level 2\n    - level 3\n      - level 4\n\n")
+	for i := range 150 {
+		source.WriteString("paragraph " + strings.Repeat("x", i%3) + "\n\n")
+	}
+	blocks := markdown.Import([]byte(source.String()))
+
+	created, err := markdown.Append(ctx, client, root, blocks)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Len(t, created, 151)
+
+	md, err := markdown.Export(ctx, client, root)
+	if assert.NoError(t, err) {
+		assert.True(t, strings.HasPrefix(md, "- level 1\n  - level 2\n    - level 3\n      - level 4\n\nparagraph"), md[:80])
+	}
+}
+
+func TestAppendLimits(t *testing.T) {
+	ctx := context.Background()
+	server := notiontest.NewServer()
+	defer server.Close()
+
+	client := server.NewClient()
+	root := server.AddPage("Root")
+
+	paragraphs := func(n int) []notion.Block {
+		blocks := make([]notion.Block, n)
+		for i := range blocks {
+			blocks[i] = notion.Block{Paragraph: &notion.BlockParagraph{RichText: notion.NewRichTextArray("paragraph")}}
+		}
+		return blocks
+	}
+	children := func(id uuid.UUID) []notion.Block {
+		return lo.Must(client.RetrieveBlockChildren(ctx, id, notion.RetrieveBlockChildrenParams{"page_size": 100})).Results
+	}
+
+	t.Run("total", func(t *testing.T) {
+		// 各配列は100以下でも、合計が1000を超えるため複数のリクエストに分割されます
+		toggles := make([]notion.Block, 20)
+		for i := range toggles {
+			toggles[i] = notion.Block{Toggle: &notion.BlockToggle{RichText: notion.NewRichTextArray("toggle"), Children: paragraphs(99)}}
+		}
+
+		requests := 0
+		counter := notion.WithMiddleware(func(next notion.Handler) notion.Handler {
+			return func(ctx context.Context, req *notion.Request) (any, error) {
+				requests++
+				return next(ctx, req)
+			}
+		})
+
+		created, err := markdown.Append(ctx, client, root, toggles, counter)
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Len(t, created, 20)
+		assert.Equal(t, 2, requests)
+		for _, toggle := range created {
+			assert.Len(t, children(toggle.Id), 99)
+		}
+	})
+
+	t.Run("columns", func(t *testing.T) {
+		// 列の子ブロックは、列を残したまま列の作成後に追加されます
+		columnList := notion.Block{ColumnList: &notion.BlockColumnList{Children: []notion.Block{
+			{Column: &notion.BlockColumn{Children: paragraphs(2)}},
+			{Column: &notion.BlockColumn{Children: []notion.Block{
+				{BulletedListItem: &notion.BlockBulletedListItem{RichText: notion.NewRichTextArray("item"), Children: paragraphs(1)}},
+			}}},
+		}}}
+
+		created, err := markdown.Append(ctx, client, root, []notion.Block{columnList})
+		if !assert.NoError(t, err) || !assert.Len(t, created, 1) {
+			return
+		}
+
+		columns := children(created[0].Id)
+		if assert.Len(t, columns, 2) {
+			assert.Len(t, children(columns[0].Id), 2)
+			if items := children(columns[1].Id); assert.Len(t, items, 1) {
+				assert.Equal(t, "item", items[0].BulletedListItem.RichText.String())
+				assert.Len(t, children(items[0].Id), 1)
+			}
+		}
+	})
+}
@@ -0,0 +1,118 @@
+package markdown
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/psyark/notion"
+)
+
+// renderRichText はリッチテキストを Markdown のインライン要素に変換します
+// Markdown に対応する記法の無い下線は <u> で、色は無視されます
+func renderRichText(rta notion.RichTextArray) string {
+	b := &strings.Builder{}
+	for _, rt := range rta {
+		b.WriteString(renderSpan(rt))
+	}
+	// リッチテキスト中の改行はハードブレークとし、行頭の記号はブロックの記法と解釈されないようにエスケープします
+	lines := strings.Split(b.String(), "\n")
+	for i, line := range lines {
+		lines[i] = escapeLineStart(line)
+	}
+	return strings.Join(lines, "\\\n")
+}
+
+func renderSpan(rt notion.RichText) string {
+	var text string
+	switch {
+	case rt.Equation != nil:
+		return "$" + rt.Equation.Expression + "$"
+	case rt.Mention != nil && rt.Mention.User != nil:
+		text = escapeText(rt.PlainText)
+		if !strings.HasPrefix(rt.PlainText, "@") {
+			text = "@" + text
+		}
+	case rt.Annotations.Code:
+		text = codeSpan(rt.PlainText)
+	default:
+		text = escapeText(rt.PlainText)
+	}
+	if text == "" {
+		return ""
+	}
+
+	// 前後の空白が強調の記号の内側にあると強調として解釈されないため、外側に出します
+	trimmed := strings.TrimSpace(text)
+	if trimmed == "" {
+		return text
+	}
+	start := strings.Index(text, trimmed)
+	leading, trailing := text[:start], text[start+len(trimmed):]
+	text = trimmed
+
+	a := rt.Annotations
+	if a.Underline {
+		text = "<u>" + text + "</u>"
+	}
+	if a.Strikethrough {
+		text = "~~" + text + "~~"
+	}
+	if a.Italic {
+		text = "*" + text + "*"
+	}
+	if a.Bold {
+		text = "**" + text + "**"
+	}
+	if rt.Href != nil && *rt.Href != "" {
+		text = "[" + text + "](" + linkDestination(*rt.Href) + ")"
+	}
+	return leading + text + trailing
+}
+
+// codeSpan は s をコードスパンにします。区切りには s に含まれない長さのバッククォートを使用します
+func codeSpan(s string) string {
+	if s == "" {
+		return ""
+	}
+	delimiter := "`"
+	for strings.Contains(s, delimiter) {
+		delimiter += "`"
+	}
+	if strings.HasPrefix(s, "`") || strings.HasSuffix(s, "`") {
+		s = " " + s + " "
+	}
+	return delimiter + s + delimiter
+}
+
+var textEscaper = strings.NewReplacer(
+	`\`, `\\`, "`", "\\`", `*`, `\*`, `_`, `\_`, `[`, `\[`, `]`, `\]`,
+	`<`, `\<`, `>`, `\>`, `|`, `\|`, `~`, `\~`, `$`, `\$`,
+)
+
+// escapeText は s に含まれるインラインの記法の記号をエスケープします
+func escapeText(s string) string {
+	return textEscaper.Replace(s)
+}
+
+var blockMarker = regexp.MustCompile(`^(\s*)(#|[-+=]|\d+[.)])`)
+
+// escapeLineStart は、行頭で見出しやリストと解釈される記号をエスケープします
+func escapeLineStart(line string) string {
+	m := blockMarker.FindStringSubmatchIndex(line)
+	if m == nil {
+		return line
+	}
+	marker := line[m[4]:m[5]]
+	if strings.HasSuffix(marker, ".") || strings.HasSuffix(marker, ")") {
+		return line[:m[5]-1] + `\` + line[m[5]-1:]
+	}
+	return line[:m[4]] + `\` + line[m[4]:]
+}
+
+// linkDestination はリンク先のURLを、必要であれば <> で囲んで返します
+func linkDestination(url string) string {
+	if strings.ContainsAny(url, " ()<>") {
+		return "<" + strings.NewReplacer("<", "%3C", ">", "%3E").Replace(url) + ">"
+	}
+	return url
+}
@@ -0,0 +1,37 @@
+package notion
+
+import (
+	"context"
+	"net/http"
+)
+
+// Request は Middleware に渡される、1回のAPI呼び出しの内容です
+// Middleware はこれを変更することで、送信されるリクエストを変更できます
+type Request struct {
+	Endpoint    string      // 呼び出されたメソッドの名前（例: "RetrievePage"）
+	Method      string      // HTTPメソッド
+	Path        string      // パス（例: "/v1/pages/..."）
+	Query       string      // エンコードされたクエリ文字列（GET・DELETEのパラメータ）
+	Body        []byte      // JSONにマーシャルされたパラメータ（GET・DELETE以外）
+	AccessToken string      // Authorization ヘッダーに使用されるアクセストークン
+	Header      http.Header // 追加のリクエストヘッダー
+}
+
+// Handler はAPI呼び出しを実行し、デコードされた結果を返します
+// 結果の型は呼び出されたメソッドの戻り値の型（例: *Page）です
+// Middleware が next を呼ばずに nil を返した場合、メソッドはその型のゼロ値（例: nil の *Page）を返します
+type Handler func(ctx context.Context, req *Request) (any, error)
+
+// Middleware は Handler をラップして、API呼び出しの前後に処理を追加します
+// ロギングやメトリクスの収集、テナントごとのアクセストークンの差し替えなどに使用します
+//
+// 再試行やレート制限による待機は next の内部で行われるため、Middleware は1回の呼び出しにつき1回だけ実行されます。
+type Middleware func(next Handler) Handler
+
+// WithMiddleware は呼び出しに middlewares を追加します
+// 先に追加された Middleware ほど外側で実行されます
+func WithMiddleware(middlewares ...Middleware) CallOption {
+	return func(co *callOptions) {
+		co.middlewares = append(co.middlewares, middlewares...)
+	}
+}
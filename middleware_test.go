@@ -0,0 +1,112 @@
+package notion
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMiddleware(t *testing.T) {
+	ctx := context.Background()
+	userId := uuid.MustParse("c02fc1d3-db8b-45c5-a222-27595b15aea7")
+
+	logs := []string{}
+	logger := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return func(ctx context.Context, req *Request) (any, error) {
+				logs = append(logs, name+" before "+req.Endpoint+" "+req.Method+" "+req.Path)
+				result, err := next(ctx, req)
+				if user, ok := result.(*User); ok {
+					logs = append(logs, name+" after "+user.Id.String())
+				}
+				return result, err
+			}
+		}
+	}
+	tenant := func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (any, error) {
+			req.AccessToken = "tenant-token"
+			req.Header.Set("X-Tenant", "acme")
+			return next(ctx, req)
+		}
+	}
+
+	requests := []*http.Request{}
+	client := NewClient("token", WithCallOptions(WithMiddleware(logger("outer"))))
+	stub := stubResponse(&requests, http.StatusOK, `{"object":"user","id":"c02fc1d3-db8b-45c5-a222-27595b15aea7"}`)
+
+	user, err := client.RetrieveUser(ctx, userId, stub, WithMiddleware(logger("inner"), tenant))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, userId, user.Id)
+	assert.Equal(t, []string{
+		"outer before RetrieveUser GET /v1/users/c02fc1d3-db8b-45c5-a222-27595b15aea7",
+		"inner before RetrieveUser GET /v1/users/c02fc1d3-db8b-45c5-a222-27595b15aea7",
+		"inner after c02fc1d3-db8b-45c5-a222-27595b15aea7",
+		"outer after c02fc1d3-db8b-45c5-a222-27595b15aea7",
+	}, logs)
+	assert.Equal(t, "Bearer tenant-token", requests[0].Header.Get("Authorization"))
+	assert.Equal(t, "acme", requests[0].Header.Get("X-Tenant"))
+
+	t.Run("body", func(t *testing.T) {
+		bodies := []string{}
+		capture := func(next Handler) Handler {
+			return func(ctx context.Context, req *Request) (any, error) {
+				bodies = append(bodies, string(req.Body))
+				return next(ctx, req)
+			}
+		}
+		params := CreateCommentParams{}
+		params.DiscussionId(userId)
+		stub := stubResponse(&requests, http.StatusBadRequest, `{"object":"error","status":400,"code":"validation_error","message":"invalid"}`)
+
+		_, err := client.CreateComment(ctx, params, stub, WithMiddleware(capture))
+		assert.ErrorIs(t, err, ErrValidation)
+		assert.Equal(t, []string{`{"discussion_id":"c02fc1d3-db8b-45c5-a222-27595b15aea7"}`}, bodies)
+	})
+}
+
+func TestMiddlewareShortCircuit(t *testing.T) {
+	ctx := context.Background()
+	userId := uuid.MustParse("c02fc1d3-db8b-45c5-a222-27595b15aea7")
+	cached := &User{Id: userId}
+
+	requests := []*http.Request{}
+	stub := stubResponse(&requests, http.StatusOK, `{"object":"user","id":"c02fc1d3-db8b-45c5-a222-27595b15aea7"}`)
+	client := NewClient("token")
+
+	// next を呼ばずに結果を返す Middleware ではリクエストは送信されません
+	cache := func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (any, error) {
+			return cached, nil
+		}
+	}
+	user, err := client.RetrieveUser(ctx, userId, stub, WithMiddleware(cache))
+	assert.NoError(t, err)
+	assert.Same(t, cached, user)
+
+	// nil を返した場合はゼロ値になります
+	skip := func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (any, error) {
+			return nil, nil
+		}
+	}
+	user, err = client.RetrieveUser(ctx, userId, stub, WithMiddleware(skip))
+	assert.NoError(t, err)
+	assert.Nil(t, user)
+
+	// 型の異なる結果はエラーになります
+	invalid := func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (any, error) {
+			return "user", nil
+		}
+	}
+	_, err = client.RetrieveUser(ctx, userId, stub, WithMiddleware(invalid))
+	assert.ErrorContains(t, err, "unexpected result type string")
+
+	assert.Empty(t, requests)
+}
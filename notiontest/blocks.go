@@ -0,0 +1,345 @@
+package notiontest
+
+import (
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+)
+
+const (
+	maxChildren = 100  // 1回のリクエストで追加できるブロックの最大数
+	maxNesting  = 2    // 1回のリクエストで追加できるブロックの入れ子の深さ
+	maxBlocks   = 1000 // 1回のリクエストで追加できるブロックの合計の最大数
+)
+
+// blockFields はブロックの種類に依存しないフィールドです
+var blockFields = []string{"object", "id", "parent", "created_time", "created_by", "last_edited_time", "last_edited_by", "archived", "in_trash", "has_children"}
+
+// appendBlocks は parent の子としてブロックを追加し、追加されたブロックを返します
+// after が空でない場合は、そのIDのブロックの直後に追加します
+// いずれかのブロックが不正な場合は、どのブロックも追加せずにエラーを返します
+func (s *Server) appendBlocks(parent object, blocks []any, after string, path string) ([]object, error) {
+	if err := validateBlocks(blocks, path, 1); err != nil {
+		return nil, err
+	}
+
+	parentId := stringOf(parent["id"])
+	position := len(s.children[parentId])
+	if after != "" {
+		position = slices.Index(s.children[parentId], after)
+		if position == -1 {
+			return nil, errValidation("body failed validation: body.after should be a child of the block, instead was `%q`.", after)
+		}
+		position++
+	}
+
+	pending, err := s.newBlocks(blocks)
+	if err != nil {
+		return nil, err
+	}
+	created := s.storeBlocks(parent, pending, position)
+	s.touch(parent)
+	return created, nil
+}
+
+// pendingBlock は作成済みで、まだ保持されていないブロックとその子ブロックです
+type pendingBlock struct {
+	block    object
+	children []pendingBlock
+}
+
+// newBlocks はリクエストで渡された blocks とその子ブロックを作成します
+// 作成したブロックは storeBlocks を呼ぶまで保持されません
+func (s *Server) newBlocks(blocks []any) ([]pendingBlock, error) {
+	pending := []pendingBlock{}
+	for _, b := range blocks {
+		block, children, err := s.newBlock(objectOf(b))
+		if err != nil {
+			return nil, err
+		}
+		nested, err := s.newBlocks(children)
+		if err != nil {
+			return nil, err
+		}
+		pending = append(pending, pendingBlock{block: block, children: nested})
+	}
+	return pending, nil
+}
+
+// storeBlocks は pending を parent の子の position の位置に保持し、保持したブロックを返します
+func (s *Server) storeBlocks(parent object, pending []pendingBlock, position int) []object {
+	parentId := stringOf(parent["id"])
+	parentRef := object{"type": "block_id", "block_id": parentId}
+	if parent["object"] == "page" {
+		parentRef = object{"type": "page_id", "page_id": parentId}
+	}
+
+	stored := []object{}
+	for _, p := range pending {
+		id := stringOf(p.block["id"])
+		p.block["parent"] = parentRef
+		s.objects[id] = p.block
+		s.order = append(s.order, id)
+		s.children[parentId] = slices.Insert(s.children[parentId], position, id)
+		position++
+
+		s.storeBlocks(p.block, p.children, 0)
+		stored = append(stored, p.block)
+	}
+	return stored
+}
+
+// validateBlocks は、ブロックの数と入れ子の深さがAPIの制限を超えていないか検証します
+func validateBlocks(blocks []any, path string, depth int) error {
+	if depth == 1 {
+		if n := countBlocks(blocks); n > maxBlocks {
+			return errValidation("body failed validation: body.%s should contain ≤ `%d` blocks in total, instead was `%d`.", path, maxBlocks, n)
+		}
+	}
+	if len(blocks) > maxChildren {
+		return errValidation("body failed validation: body.%s.length should be ≤ `%d`, instead was `%d`.", path, maxChildren, len(blocks))
+	}
+	for i, b := range blocks {
+		block := objectOf(b)
+		typ := typeOf(block, blockFields...)
+		children := arrayOf(objectOf(block[typ])["children"])
+		if len(children) == 0 {
+			continue
+		}
+		childPath := path + "[" + strconv.Itoa(i) + "]." + typ + ".children"
+		if depth >= maxNesting {
+			return errValidation("body failed validation: body.%s should be not present, instead was `[...]`.", childPath)
+		}
+		if err := validateBlocks(children, childPath, depth+1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// countBlocks は blocks とその子孫のブロックの数を返します
+func countBlocks(blocks []any) int {
+	n := len(blocks)
+	for _, b := range blocks {
+		block := objectOf(b)
+		n += countBlocks(arrayOf(objectOf(block[typeOf(block, blockFields...)])["children"]))
+	}
+	return n
+}
+
+// newBlock はリクエストで渡されたブロックから保持するブロックを作成し、その子ブロックと共に返します
+// parent は storeBlocks で設定されます
+func (s *Server) newBlock(b object) (object, []any, error) {
+	typ := typeOf(b, blockFields...)
+	if typ == "" {
+		return nil, nil, errValidation("body failed validation: block type should be defined.")
+	}
+
+	content := maps.Clone(objectOf(b[typ]))
+	if content == nil {
+		content = object{}
+	}
+	children := arrayOf(content["children"])
+	delete(content, "children")
+	if err := normalizeBlockContent(typ, content); err != nil {
+		return nil, nil, err
+	}
+
+	ts := s.timestamp()
+	block := object{
+		"object":           "block",
+		"id":               newId(),
+		"created_time":     ts,
+		"last_edited_time": ts,
+		"created_by":       s.partialBot(),
+		"last_edited_by":   s.partialBot(),
+		"has_children":     false,
+		"archived":         false,
+		"in_trash":         false,
+		"type":             typ,
+		typ:                content,
+	}
+	return block, children, nil
+}
+
+// normalizeBlockContent はブロックの内容にNotionがレスポンスで補完するフィールドを設定します
+func normalizeBlockContent(typ string, content object) error {
+	if rt, ok := content["rich_text"]; ok {
+		rts, err := normalizeRichTexts(rt, typ+".rich_text")
+		if err != nil {
+			return err
+		}
+		content["rich_text"] = rts
+		if _, ok := content["color"]; !ok && typ != "code" {
+			content["color"] = "default"
+		}
+	}
+	if caption, ok := content["caption"]; ok {
+		rts, err := normalizeRichTexts(caption, typ+".caption")
+		if err != nil {
+			return err
+		}
+		content["caption"] = rts
+	}
+
+	switch typ {
+	case "to_do":
+		if _, ok := content["checked"]; !ok {
+			content["checked"] = false
+		}
+	case "heading_1", "heading_2", "heading_3":
+		if _, ok := content["is_toggleable"]; !ok {
+			content["is_toggleable"] = false
+		}
+	case "code":
+		if _, ok := content["caption"]; !ok {
+			content["caption"] = []any{}
+		}
+	case "table_row":
+		cells := []any{}
+		for i, cell := range arrayOf(content["cells"]) {
+			rts, err := normalizeRichTexts(cell, fmt.Sprintf("table_row.cells[%d]", i))
+			if err != nil {
+				return err
+			}
+			cells = append(cells, rts)
+		}
+		content["cells"] = cells
+	}
+	return nil
+}
+
+// blockView は、obj をブロックとして表現したものを返します
+// ページとデータベースはそれぞれ child_page、child_database ブロックとして表現されます
+func (s *Server) blockView(obj object) object {
+	id := stringOf(obj["id"])
+
+	var view object
+	switch obj["object"] {
+	case "page", "database":
+		typ := "child_" + stringOf(obj["object"])
+		view = object{"object": "block", "type": typ, typ: object{"title": titleOf(obj)}}
+		for _, key := range blockFields[1:] {
+			view[key] = obj[key]
+		}
+	default:
+		view = maps.Clone(obj)
+	}
+	view["has_children"] = len(s.visibleChildren(id)) != 0
+	return view
+}
+
+func (s *Server) retrieveBlock(r *http.Request, body object) (any, error) {
+	id, err := pathId(r, "id")
+	if err != nil {
+		return nil, err
+	}
+	obj, err := s.lookup(id, "")
+	if err != nil {
+		return nil, err
+	}
+	return s.blockView(obj), nil
+}
+
+func (s *Server) updateBlock(r *http.Request, body object) (any, error) {
+	id, err := pathId(r, "id")
+	if err != nil {
+		return nil, err
+	}
+	obj, err := s.lookup(id, "")
+	if err != nil {
+		return nil, err
+	}
+	if obj["in_trash"] == true && !restoring(body) {
+		return nil, errArchived()
+	}
+
+	if obj["object"] == "block" {
+		typ := stringOf(obj["type"])
+		for key, v := range body {
+			if slices.Contains(blockFields, key) || key == "type" {
+				continue
+			}
+			if key != typ {
+				return nil, errValidation("body failed validation: body.%s should be not present, the block type is %s.", key, typ)
+			}
+			content := maps.Clone(objectOf(obj[typ]))
+			maps.Copy(content, objectOf(v))
+			if err := normalizeBlockContent(typ, content); err != nil {
+				return nil, err
+			}
+			obj[typ] = content
+		}
+	}
+
+	setTrash(obj, body)
+	s.touch(obj)
+	return s.blockView(obj), nil
+}
+
+func (s *Server) deleteBlock(r *http.Request, body object) (any, error) {
+	id, err := pathId(r, "id")
+	if err != nil {
+		return nil, err
+	}
+	obj, err := s.lookup(id, "")
+	if err != nil {
+		return nil, err
+	}
+	setTrash(obj, object{"in_trash": true})
+	s.touch(obj)
+	return s.blockView(obj), nil
+}
+
+func (s *Server) retrieveBlockChildren(r *http.Request, body object) (any, error) {
+	id, err := pathId(r, "id")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.lookup(id, ""); err != nil {
+		return nil, err
+	}
+
+	children := []object{}
+	for _, child := range s.visibleChildren(id) {
+		children = append(children, s.blockView(child))
+	}
+	return paginateObjects(r, body, children, "block")
+}
+
+func (s *Server) appendBlockChildren(r *http.Request, body object) (any, error) {
+	id, err := pathId(r, "id")
+	if err != nil {
+		return nil, err
+	}
+	parent, err := s.lookup(id, "")
+	if err != nil {
+		return nil, err
+	}
+	if parent["object"] == "database" {
+		return nil, errValidation("Block type child_database does not support children.")
+	}
+	if parent["in_trash"] == true {
+		return nil, errArchived()
+	}
+
+	created, err := s.appendBlocks(parent, arrayOf(body["children"]), stringOf(body["after"]), "children")
+	if err != nil {
+		return nil, err
+	}
+
+	results := []object{}
+	for _, block := range created {
+		results = append(results, s.blockView(block))
+	}
+	return object{
+		"object":      "list",
+		"results":     results,
+		"next_cursor": nil,
+		"has_more":    false,
+		"type":        "block",
+		"block":       object{},
+	}, nil
+}
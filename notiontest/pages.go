@@ -0,0 +1,792 @@
+package notiontest
+
+import (
+	"maps"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/psyark/notion"
+)
+
+// newPage は parent の子として新しいページを作成します
+func (s *Server) newPage(parent object, properties object) object {
+	ts := s.timestamp()
+	page := object{
+		"object":           "page",
+		"id":               newId(),
+		"created_time":     ts,
+		"last_edited_time": ts,
+		"created_by":       s.partialBot(),
+		"last_edited_by":   s.partialBot(),
+		"cover":            nil,
+		"icon":             nil,
+		"parent":           parent,
+		"archived":         false,
+		"in_trash":         false,
+		"properties":       properties,
+		"public_url":       nil,
+	}
+	page["url"] = notionURL(page)
+
+	parentId := ""
+	if parent["type"] == "page_id" || parent["type"] == "block_id" {
+		parentId = stringOf(parent[stringOf(parent["type"])])
+	}
+	s.add(page, parentId)
+	return page
+}
+
+func (s *Server) createPage(r *http.Request, body object) (any, error) {
+	parent, parentType, parentId, err := parseParent(body["parent"])
+	if err != nil {
+		return nil, err
+	}
+
+	var schema object
+	switch parentType {
+	case "page_id":
+		parentPage, err := s.lookup(parentId, "page")
+		if err != nil {
+			return nil, err
+		}
+		if parentPage["in_trash"] == true {
+			return nil, errArchived()
+		}
+	case "database_id":
+		db, err := s.lookup(parentId, "database")
+		if err != nil {
+			return nil, err
+		}
+		if db["in_trash"] == true {
+			return nil, errArchived()
+		}
+		schema = objectOf(db["properties"])
+	default:
+		return nil, errValidation("body failed validation: body.parent.type should be page_id or database_id, instead was `%q`.", parentType)
+	}
+
+	properties, err := s.pageProperties(schema, object{}, objectOf(body["properties"]))
+	if err != nil {
+		return nil, err
+	}
+
+	// ページを保持する前に子ブロックを検証し、不正な場合はページを作成しません
+	children := arrayOf(body["children"])
+	if err := validateBlocks(children, "children", 1); err != nil {
+		return nil, err
+	}
+	pending, err := s.newBlocks(children)
+	if err != nil {
+		return nil, err
+	}
+
+	page := s.newPage(parent, properties)
+	if err := s.updatePageFields(page, body); err != nil {
+		return nil, err
+	}
+	s.computeProperties(page, schema)
+	s.storeBlocks(page, pending, 0)
+	return page, nil
+}
+
+func (s *Server) retrievePage(r *http.Request, body object) (any, error) {
+	id, err := pathId(r, "id")
+	if err != nil {
+		return nil, err
+	}
+	return s.lookup(id, "page")
+}
+
+func (s *Server) updatePage(r *http.Request, body object) (any, error) {
+	id, err := pathId(r, "id")
+	if err != nil {
+		return nil, err
+	}
+	page, err := s.lookup(id, "page")
+	if err != nil {
+		return nil, err
+	}
+	if page["in_trash"] == true && !restoring(body) {
+		return nil, errArchived()
+	}
+
+	schema := s.pageSchema(page)
+	if properties, ok := body["properties"]; ok {
+		updated, err := s.pageProperties(schema, objectOf(page["properties"]), objectOf(properties))
+		if err != nil {
+			return nil, err
+		}
+		page["properties"] = updated
+	}
+	if err := s.updatePageFields(page, body); err != nil {
+		return nil, err
+	}
+	setTrash(page, body)
+	s.touch(page)
+	s.computeProperties(page, schema)
+	return page, nil
+}
+
+// updatePageFields はページまたはデータベースのアイコンとカバーを更新します
+func (s *Server) updatePageFields(obj object, body object) error {
+	for _, key := range []string{"icon", "cover"} {
+		if v, ok := body[key]; ok {
+			if v != nil && objectOf(v) == nil {
+				return errValidation("body failed validation: body.%s should be an object or `null`.", key)
+			}
+			obj[key] = v
+		}
+	}
+	return nil
+}
+
+// pageSchema は、データベースに属するページの場合はそのデータベースのプロパティを返します
+func (s *Server) pageSchema(page object) object {
+	parent := objectOf(page["parent"])
+	if parent["type"] != "database_id" {
+		return nil
+	}
+	return objectOf(s.objects[stringOf(parent["database_id"])]["properties"])
+}
+
+// pageProperties は、現在のプロパティ値 current に input を適用した結果を返します
+// schema が nil の場合（データベースに属さないページ）は title のみを受け付けます
+func (s *Server) pageProperties(schema object, current object, input object) (object, error) {
+	if schema == nil {
+		schema = object{"title": object{"id": "title", "name": "title", "type": "title", "title": object{}}}
+	}
+
+	result := object{}
+	for name, prop := range schema {
+		prop := objectOf(prop)
+		if value, ok := current[name]; ok {
+			result[name] = value
+		} else {
+			result[name] = propertyValue(prop, emptyValue(stringOf(prop["type"])))
+		}
+	}
+
+	for name, value := range input {
+		prop := findProperty(schema, name)
+		if prop == nil {
+			return nil, errValidation("%s is not a property that exists.", name)
+		}
+
+		typ := stringOf(prop["type"])
+		valueObj := objectOf(value)
+		if valueObj == nil {
+			return nil, errValidation("body failed validation: body.properties.%s should be an object.", name)
+		}
+		if t := stringOf(valueObj["type"]); t != "" && t != typ {
+			return nil, errValidation("%s is expected to be %s.", name, typ)
+		}
+		if isComputed(typ) {
+			return nil, errValidation("%s is expected to be %s, which cannot be updated.", name, typ)
+		}
+
+		v, err := normalizeValue(prop, valueObj[typ], "properties."+name)
+		if err != nil {
+			return nil, err
+		}
+		result[stringOf(prop["name"])] = propertyValue(prop, v)
+	}
+	return result, nil
+}
+
+// findProperty は名前またはIDが nameOrId に一致するプロパティを schema から探します
+func findProperty(schema object, nameOrId string) object {
+	if prop, ok := schema[nameOrId]; ok {
+		return objectOf(prop)
+	}
+	for _, prop := range schema {
+		if prop := objectOf(prop); prop["id"] == nameOrId {
+			return prop
+		}
+	}
+	return nil
+}
+
+func propertyValue(prop object, value any) object {
+	typ := stringOf(prop["type"])
+	pv := object{"id": prop["id"], "type": typ, typ: value}
+	if typ == "relation" {
+		pv["has_more"] = false
+	}
+	return pv
+}
+
+// emptyValue はプロパティの種類ごとの、値が設定されていない状態を返します
+func emptyValue(typ string) any {
+	switch typ {
+	case "title", "rich_text", "multi_select", "people", "files", "relation":
+		return []any{}
+	case "checkbox":
+		return false
+	default:
+		return nil
+	}
+}
+
+// isComputed は、APIから値を設定できない種類のプロパティかを返します
+func isComputed(typ string) bool {
+	switch typ {
+	case "created_time", "created_by", "last_edited_time", "last_edited_by", "formula", "rollup", "unique_id", "button":
+		return true
+	default:
+		return false
+	}
+}
+
+// normalizeValue はプロパティ値をレスポンスの形式に正規化します
+// セレクトの選択肢が存在しない場合は、Notionと同様にスキーマに追加します
+func normalizeValue(prop object, v any, path string) (any, error) {
+	typ := stringOf(prop["type"])
+	if v == nil {
+		return emptyValue(typ), nil
+	}
+
+	switch typ {
+	case "title", "rich_text":
+		return normalizeRichTexts(v, path+"."+typ)
+	case "select", "status":
+		return selectOption(prop, objectOf(v))
+	case "multi_select":
+		options := []any{}
+		for _, o := range arrayOf(v) {
+			option, err := selectOption(prop, objectOf(o))
+			if err != nil {
+				return nil, err
+			}
+			options = append(options, option)
+		}
+		return options, nil
+	default:
+		return v, nil
+	}
+}
+
+// selectOption は名前またはIDで選択肢を探し、存在しない場合は作成します
+func selectOption(prop object, value object) (object, error) {
+	typ := stringOf(prop["type"])
+	config := objectOf(prop[typ])
+	options := arrayOf(config["options"])
+	for _, o := range options {
+		option := objectOf(o)
+		if (value["id"] != nil && option["id"] == value["id"]) || (value["name"] != nil && option["name"] == value["name"]) {
+			return optionValue(option), nil
+		}
+	}
+
+	if typ == "status" || stringOf(value["name"]) == "" {
+		return nil, errValidation("Invalid %s option: %v", typ, value)
+	}
+	option := object{"id": newPropertyId(), "name": value["name"], "color": "default", "description": nil}
+	if color := stringOf(value["color"]); color != "" {
+		option["color"] = color
+	}
+	config["options"] = append(options, option)
+	return optionValue(option), nil
+}
+
+// optionValue は、スキーマの選択肢をプロパティ値として表現したもの（description を含まない）を返します
+func optionValue(option object) object {
+	value := maps.Clone(option)
+	delete(value, "description")
+	return value
+}
+
+// computeProperties は、作成日時などの計算されるプロパティの値を設定します
+func (s *Server) computeProperties(page object, schema object) {
+	properties := objectOf(page["properties"])
+	for name, prop := range schema {
+		prop := objectOf(prop)
+		typ := stringOf(prop["type"])
+		var value any
+		switch typ {
+		case "created_time", "last_edited_time":
+			value = page[typ]
+		case "created_by", "last_edited_by":
+			value = page[typ]
+		case "formula":
+			value = object{"type": "string", "string": nil}
+		case "rollup":
+			value = object{"type": "array", "array": []any{}, "function": objectOf(prop["rollup"])["function"]}
+		case "button":
+			value = object{}
+		case "unique_id":
+			if current := objectOf(objectOf(properties[name])["unique_id"]); current != nil {
+				value = current
+			} else {
+				value = object{"prefix": objectOf(prop["unique_id"])["prefix"], "number": s.nextUniqueId(page)}
+			}
+		default:
+			continue
+		}
+		properties[name] = propertyValue(prop, value)
+	}
+}
+
+// nextUniqueId は、page が属するデータベースの次の一意IDの番号を返します
+func (s *Server) nextUniqueId(page object) int {
+	parent := objectOf(page["parent"])
+	number := 0
+	for _, obj := range s.objects {
+		if p := objectOf(obj["parent"]); obj["object"] == "page" && p["database_id"] == parent["database_id"] {
+			number++
+		}
+	}
+	return number
+}
+
+func (s *Server) retrievePagePropertyItem(r *http.Request, body object) (any, error) {
+	id, err := pathId(r, "id")
+	if err != nil {
+		return nil, err
+	}
+	page, err := s.lookup(id, "page")
+	if err != nil {
+		return nil, err
+	}
+
+	// プロパティIDはURLエンコードされた形（例: "%3Dh%3AT"）で保持されますが、パスではデコードされて渡されます
+	propertyId := r.PathValue("property_id")
+	var value object
+	for _, v := range objectOf(page["properties"]) {
+		v := objectOf(v)
+		id := stringOf(v["id"])
+		if unescaped, err := url.PathUnescape(id); id == propertyId || (err == nil && unescaped == propertyId) {
+			value = v
+		}
+	}
+	if value == nil {
+		return nil, errNotFound("property", propertyId)
+	}
+	propertyId = stringOf(value["id"])
+
+	typ := stringOf(value["type"])
+	switch typ {
+	case "title", "rich_text", "relation", "people":
+		// これらのプロパティはプロパティアイテムのリストとしてページネーションされます
+		items := []object{}
+		cursors := []string{}
+		for i, v := range arrayOf(value[typ]) {
+			items = append(items, object{"object": "property_item", "id": propertyId, "type": typ, typ: v})
+			cursors = append(cursors, strconv.Itoa(i))
+		}
+		startCursor, pageSize, err := pageRequest(r, body)
+		if err != nil {
+			return nil, err
+		}
+		list, err := paginate(items, cursors, startCursor, pageSize, "property_item")
+		if err != nil {
+			return nil, err
+		}
+		list["property_item"] = object{"id": propertyId, "next_url": nil, "type": typ, typ: object{}}
+		return list, nil
+	default:
+		return object{"object": "property_item", "id": propertyId, "type": typ, typ: value[typ]}, nil
+	}
+}
+
+func (s *Server) createDatabase(r *http.Request, body object) (any, error) {
+	parent, parentType, parentId, err := parseParent(body["parent"])
+	if err != nil {
+		return nil, err
+	}
+	if parentType != "page_id" {
+		return nil, errValidation("body failed validation: body.parent.type should be page_id, instead was `%q`.", parentType)
+	}
+	parentPage, err := s.lookup(parentId, "page")
+	if err != nil {
+		return nil, err
+	}
+	if parentPage["in_trash"] == true {
+		return nil, errArchived()
+	}
+
+	properties, err := normalizeSchema(object{}, objectOf(body["properties"]))
+	if err != nil {
+		return nil, err
+	}
+	s.resolveRollups(properties)
+	if !slices.ContainsFunc(slices.Collect(maps.Values(properties)), func(p any) bool { return objectOf(p)["type"] == "title" }) {
+		return nil, errValidation("Title property must be specified in the database schema.")
+	}
+
+	return s.newDatabase(newId(), parent, parentId, properties, body)
+}
+
+// newDatabase は、parent の子として properties をスキーマに持つデータベースを作成します
+// タイトルなどは body に従って設定されます
+func (s *Server) newDatabase(id string, parent object, parentId string, properties object, body object) (object, error) {
+	ts := s.timestamp()
+	db := object{
+		"object":           "database",
+		"id":               id,
+		"created_time":     ts,
+		"last_edited_time": ts,
+		"created_by":       s.partialBot(),
+		"last_edited_by":   s.partialBot(),
+		"title":            []any{},
+		"description":      []any{},
+		"icon":             nil,
+		"cover":            nil,
+		"properties":       properties,
+		"parent":           parent,
+		"archived":         false,
+		"in_trash":         false,
+		"is_inline":        body["is_inline"] == true,
+		"public_url":       nil,
+	}
+	db["url"] = notionURL(db)
+	if err := s.updateDatabaseFields(db, body); err != nil {
+		return nil, err
+	}
+	s.add(db, parentId)
+	return db, nil
+}
+
+func (s *Server) retrieveDatabase(r *http.Request, body object) (any, error) {
+	id, err := pathId(r, "id")
+	if err != nil {
+		return nil, err
+	}
+	return s.lookup(id, "database")
+}
+
+func (s *Server) updateDatabase(r *http.Request, body object) (any, error) {
+	id, err := pathId(r, "id")
+	if err != nil {
+		return nil, err
+	}
+	db, err := s.lookup(id, "database")
+	if err != nil {
+		return nil, err
+	}
+	if db["in_trash"] == true && !restoring(body) {
+		return nil, errArchived()
+	}
+
+	if input, ok := body["properties"]; ok {
+		properties, err := normalizeSchema(objectOf(db["properties"]), objectOf(input))
+		if err != nil {
+			return nil, err
+		}
+		s.resolveRollups(properties)
+		db["properties"] = properties
+		s.migratePages(db)
+	}
+	if err := s.updateDatabaseFields(db, body); err != nil {
+		return nil, err
+	}
+	if v, ok := body["is_inline"].(bool); ok {
+		db["is_inline"] = v
+	}
+	setTrash(db, body)
+	s.touch(db)
+	return db, nil
+}
+
+// updateDatabaseFields はデータベースのタイトル・説明・アイコン・カバーを更新します
+func (s *Server) updateDatabaseFields(db object, body object) error {
+	for _, key := range []string{"title", "description"} {
+		if v, ok := body[key]; ok {
+			rts, err := normalizeRichTexts(v, key)
+			if err != nil {
+				return err
+			}
+			db[key] = rts
+		}
+	}
+	return s.updatePageFields(db, body)
+}
+
+// migratePages は、スキーマの変更に合わせてデータベースに属するページのプロパティを更新します
+func (s *Server) migratePages(db object) {
+	schema := objectOf(db["properties"])
+	for _, page := range s.databasePages(stringOf(db["id"]), true) {
+		current := object{}
+		for _, value := range objectOf(page["properties"]) {
+			value := objectOf(value)
+			for name, prop := range schema {
+				if prop := objectOf(prop); prop["id"] == value["id"] && prop["type"] == value["type"] {
+					current[name] = value
+				}
+			}
+		}
+		page["properties"], _ = s.pageProperties(schema, current, nil)
+		s.computeProperties(page, schema)
+	}
+}
+
+// normalizeSchema は、現在のスキーマ current に input を適用した結果を返します
+// input の値が null のプロパティは削除され、name を持つ値はプロパティの名前を変更します
+func normalizeSchema(current object, input object) (object, error) {
+	result := maps.Clone(current)
+	for name, v := range input {
+		existing := findProperty(result, name)
+		if v == nil {
+			if existing != nil {
+				delete(result, stringOf(existing["name"]))
+			}
+			continue
+		}
+
+		value := objectOf(v)
+		if value == nil {
+			return nil, errValidation("body failed validation: body.properties.%s should be an object or `null`.", name)
+		}
+
+		prop := object{}
+		if existing != nil {
+			prop = maps.Clone(existing)
+			delete(result, stringOf(existing["name"]))
+		} else {
+			prop["id"] = newPropertyId()
+		}
+
+		if newName := stringOf(value["name"]); newName != "" {
+			name = newName
+		}
+		prop["name"] = name
+		if _, ok := prop["description"]; !ok {
+			prop["description"] = ""
+		}
+
+		if typ := typeOf(value, "id", "name", "description"); typ != "" {
+			if prop["type"] != typ {
+				delete(prop, stringOf(prop["type"]))
+			}
+			prop["type"] = typ
+			prop[typ] = normalizeSchemaConfig(typ, objectOf(value[typ]))
+			if typ == "title" {
+				prop["id"] = "title"
+			}
+		}
+		if prop["type"] == nil {
+			return nil, errValidation("body failed validation: body.properties.%s should specify a property type.", name)
+		}
+		result[name] = prop
+	}
+	return result, nil
+}
+
+// resolveRollups は、rollup プロパティの関連プロパティと集計するプロパティについて、名前とIDのうち指定されなかった方を補完します
+func (s *Server) resolveRollups(properties object) {
+	for _, p := range properties {
+		prop := objectOf(p)
+		if prop["type"] != "rollup" {
+			continue
+		}
+		config := objectOf(prop["rollup"])
+		relation := findProperty(properties, stringOf(firstOf(config["relation_property_name"], config["relation_property_id"])))
+		if relation == nil {
+			continue
+		}
+		config["relation_property_name"], config["relation_property_id"] = relation["name"], relation["id"]
+
+		related := s.objects[stringOf(objectOf(relation["relation"])["database_id"])]
+		target := findProperty(objectOf(related["properties"]), stringOf(firstOf(config["rollup_property_name"], config["rollup_property_id"])))
+		if target != nil {
+			config["rollup_property_name"], config["rollup_property_id"] = target["name"], target["id"]
+		}
+	}
+}
+
+// firstOf は values のうち最初の nil でない値を返します
+func firstOf(values ...any) any {
+	for _, v := range values {
+		if v != nil {
+			return v
+		}
+	}
+	return nil
+}
+
+// normalizeSchemaConfig はプロパティの種類ごとの設定に既定値を補完します
+func normalizeSchemaConfig(typ string, config object) object {
+	config = maps.Clone(config)
+	if config == nil {
+		config = object{}
+	}
+	switch typ {
+	case "number":
+		if stringOf(config["format"]) == "" {
+			config["format"] = "number"
+		}
+	case "select", "multi_select", "status":
+		options := []any{}
+		for _, o := range arrayOf(config["options"]) {
+			option := maps.Clone(objectOf(o))
+			if stringOf(option["id"]) == "" {
+				option["id"] = newPropertyId()
+			}
+			if stringOf(option["color"]) == "" {
+				option["color"] = "default"
+			}
+			if _, ok := option["description"]; !ok {
+				option["description"] = nil
+			}
+			options = append(options, option)
+		}
+		config["options"] = options
+	}
+	return config
+}
+
+func (s *Server) queryDatabase(r *http.Request, body object) (any, error) {
+	id, err := pathId(r, "id")
+	if err != nil {
+		return nil, err
+	}
+	db, err := s.lookup(id, "database")
+	if err != nil {
+		return nil, err
+	}
+	pages := s.databasePages(id, false)
+	if body["filter"] != nil || body["sorts"] != nil {
+		if pages, err = filterPages(db, pages, body); err != nil {
+			return nil, err
+		}
+	}
+	return paginateObjects(r, body, pages, "page_or_database")
+}
+
+// filterPages は body の filter と sorts に従ってデータベースのページを絞り込み、並べ替えます
+func filterPages(db object, pages []object, body object) ([]object, error) {
+	schema := map[string]notion.Property{}
+	if err := decode(db["properties"], &schema); err != nil {
+		return nil, err
+	}
+
+	var filter *notion.Filter
+	if body["filter"] != nil {
+		filter = &notion.Filter{}
+		if err := decode(body["filter"], filter); err != nil {
+			return nil, errValidation("body failed validation: body.filter is invalid: %v", err)
+		}
+	}
+	sorts := []notion.Sort{}
+	if body["sorts"] != nil {
+		if err := decode(body["sorts"], &sorts); err != nil {
+			return nil, errValidation("body failed validation: body.sorts is invalid: %v", err)
+		}
+	}
+
+	matched := []notion.Page{}
+	objects := map[uuid.UUID]object{}
+	for _, obj := range pages {
+		page := notion.Page{}
+		if err := decode(obj, &page); err != nil {
+			return nil, err
+		}
+		if filter != nil {
+			ok, err := filter.Match(&page, schema)
+			if err != nil {
+				return nil, errValidation("body failed validation: body.filter: %v", err)
+			}
+			if !ok {
+				continue
+			}
+		}
+		matched = append(matched, page)
+		objects[page.Id] = obj
+	}
+
+	if err := notion.SortPages(matched, sorts, schema); err != nil {
+		return nil, errValidation("body failed validation: body.sorts: %v", err)
+	}
+
+	results := make([]object, len(matched))
+	for i, page := range matched {
+		results[i] = objects[page.Id]
+	}
+	return results, nil
+}
+
+// databasePages はデータベースに属するページを作成順に返します
+func (s *Server) databasePages(databaseId string, includeTrash bool) []object {
+	pages := []object{}
+	for _, id := range s.order {
+		obj := s.objects[id]
+		if obj["object"] == "page" && objectOf(obj["parent"])["database_id"] == databaseId && (includeTrash || obj["in_trash"] != true) {
+			pages = append(pages, obj)
+		}
+	}
+	return pages
+}
+
+func (s *Server) search(r *http.Request, body object) (any, error) {
+	query := strings.ToLower(stringOf(body["query"]))
+
+	kind := ""
+	if filter := objectOf(body["filter"]); filter != nil {
+		if filter["property"] != "object" || (filter["value"] != "page" && filter["value"] != "database") {
+			return nil, errValidation("body failed validation: body.filter.value should be `\"page\"` or `\"database\"`, instead was `%v`.", filter["value"])
+		}
+		kind = stringOf(filter["value"])
+	}
+
+	direction := "descending"
+	if sort := objectOf(body["sort"]); sort != nil {
+		if sort["timestamp"] != "last_edited_time" || (sort["direction"] != "ascending" && sort["direction"] != "descending") {
+			return nil, errValidation("body failed validation: body.sort should be sorted by `last_edited_time` in ascending or descending direction.")
+		}
+		direction = stringOf(sort["direction"])
+	}
+
+	results := []object{}
+	for _, id := range s.order {
+		obj := s.objects[id]
+		if obj["object"] == "block" || obj["in_trash"] == true || (kind != "" && obj["object"] != kind) {
+			continue
+		}
+		if query != "" && !strings.Contains(strings.ToLower(titleOf(obj)), query) {
+			continue
+		}
+		results = append(results, obj)
+	}
+
+	// 最終更新日時は分単位のため、同じ日時の場合は作成順を維持します
+	slices.Reverse(results)
+	slices.SortStableFunc(results, func(a, b object) int {
+		return strings.Compare(stringOf(b["last_edited_time"]), stringOf(a["last_edited_time"]))
+	})
+	if direction == "ascending" {
+		slices.Reverse(results)
+	}
+	return paginateObjects(r, body, results, "page_or_database")
+}
+
+// titleOf はページまたはデータベースのタイトルをプレーンテキストで返します
+func titleOf(obj object) string {
+	if obj["object"] == "database" {
+		return plainText(obj["title"])
+	}
+	for _, value := range objectOf(obj["properties"]) {
+		if value := objectOf(value); value["type"] == "title" {
+			return plainText(value["title"])
+		}
+	}
+	return ""
+}
+
+// notionURL はページまたはデータベースのURLを返します
+func notionURL(obj object) string {
+	return "https://www.notion.so/" + strings.ReplaceAll(stringOf(obj["id"]), "-", "")
+}
+
+// newPropertyId はプロパティや選択肢のIDとして使われる短い文字列を返します
+func newPropertyId() string {
+	const letters = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+	b := make([]byte, 4)
+	for i := range b {
+		b[i] = letters[rand.IntN(len(letters))]
+	}
+	return string(b)
+}
@@ -0,0 +1,65 @@
+package notiontest
+
+import (
+	"maps"
+	"unicode/utf16"
+)
+
+// maxTextLength はリッチテキスト1つあたりの最大文字数（UTF-16のコード単位）です
+const maxTextLength = 2000
+
+// textArray は content を含むリッチテキストの配列を返します
+func textArray(content string) []any {
+	return []any{object{"type": "text", "text": object{"content": content}}}
+}
+
+// normalizeRichTexts は、リクエストで渡されたリッチテキストの配列に
+// Notionがレスポンスで補完するフィールド（annotations、plain_text、href）を設定します
+// path はエラーメッセージに使用されます
+func normalizeRichTexts(v any, path string) ([]any, error) {
+	items := arrayOf(v)
+	result := make([]any, 0, len(items))
+	for i, item := range items {
+		rt := maps.Clone(objectOf(item))
+		if rt == nil {
+			return nil, errValidation("body failed validation: body.%s[%d] should be an object.", path, i)
+		}
+
+		typ := typeOf(rt, "annotations", "plain_text", "href")
+		rt["type"] = typ
+
+		annotations := object{"bold": false, "italic": false, "strikethrough": false, "underline": false, "code": false, "color": "default"}
+		maps.Copy(annotations, objectOf(rt["annotations"]))
+		rt["annotations"] = annotations
+
+		switch typ {
+		case "text":
+			text := maps.Clone(objectOf(rt["text"]))
+			content := stringOf(text["content"])
+			if n := len(utf16.Encode([]rune(content))); n > maxTextLength {
+				return nil, errValidation("body failed validation: body.%s[%d].text.content.length should be ≤ `%d`, instead was `%d`.", path, i, maxTextLength, n)
+			}
+			link := objectOf(text["link"])
+			text["link"] = nil
+			rt["href"] = nil
+			if link != nil {
+				text["link"] = link
+				rt["href"] = link["url"]
+			}
+			rt["text"] = text
+			rt["plain_text"] = content
+		case "equation":
+			rt["plain_text"] = stringOf(objectOf(rt["equation"])["expression"])
+			rt["href"] = nil
+		case "mention":
+			rt["plain_text"] = stringOf(rt["plain_text"])
+			if _, ok := rt["href"]; !ok {
+				rt["href"] = nil
+			}
+		default:
+			return nil, errValidation("body failed validation: body.%s[%d].type should be one of text, mention, equation, instead was `%q`.", path, i, typ)
+		}
+		result = append(result, rt)
+	}
+	return result, nil
+}
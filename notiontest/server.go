@@ -0,0 +1,507 @@
+// Package notiontest は、テスト用のインメモリなNotion APIサーバーを提供します
+//
+// Server はページ、データベース、ブロック、ユーザー、コメントをメモリ上に保持し、
+// notion.Client が公開する全てのエンドポイントを実装します。
+// ネットワークやNotionのワークスペースを必要とせずに、Client を使ったコードをテストできます。
+//
+//	server := notiontest.NewServer()
+//	defer server.Close()
+//
+//	client := server.NewClient()
+//	root := server.AddPage("Root")
+package notiontest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/psyark/notion"
+	notionjson "github.com/psyark/notion/json"
+)
+
+// object はJSONオブジェクトとして保持されるNotionのオブジェクトです
+type object = map[string]any
+
+// Server はインメモリなNotion APIサーバーです
+type Server struct {
+	*httptest.Server
+
+	Token string // 受け付けるアクセストークン
+
+	mu       sync.Mutex
+	objects  map[string]object   // ページ、データベース、ブロック（IDをキーとする）
+	order    []string            // objects のIDの作成順
+	children map[string][]string // 親のIDをキーとする、子のページ・データベース・ブロックのIDの一覧
+	users    []object
+	comments []object
+	botId    string
+	now      func() time.Time
+}
+
+// NewServer は新しい Server を起動して返します
+// 使用後は Close を呼び出してください
+func NewServer() *Server {
+	s := &Server{
+		Token:    "secret_notiontest",
+		objects:  map[string]object{},
+		children: map[string][]string{},
+		now:      time.Now,
+	}
+
+	s.botId = newId()
+	s.users = append(s.users, object{
+		"object":     "user",
+		"id":         s.botId,
+		"name":       "notiontest",
+		"avatar_url": nil,
+		"type":       "bot",
+		"bot": object{
+			"owner":          object{"type": "workspace", "workspace": true},
+			"workspace_name": "notiontest",
+		},
+	})
+
+	s.Server = httptest.NewServer(s.handler())
+	return s
+}
+
+// NewClient は、このサーバーにリクエストを送信する notion.Client を返します
+func (s *Server) NewClient(options ...notion.ClientOption) *notion.Client {
+	options = append([]notion.ClientOption{
+		notion.WithBaseURL(s.URL),
+		notion.WithHTTPClient(s.Client()),
+	}, options...)
+	return notion.NewClient(s.Token, options...)
+}
+
+// AddPage は、ワークスペース直下に title という名前のページを作成し、そのIDを返します
+// APIからはワークスペース直下にページを作成できないため、テストの起点となるページの作成に使用します
+func (s *Server) AddPage(title string) uuid.UUID {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	titleValue, _ := normalizeRichTexts(textArray(title), "title")
+	page := s.newPage(object{"type": "workspace", "workspace": true}, object{
+		"title": object{"id": "title", "type": "title", "title": titleValue},
+	})
+	return uuid.MustParse(page["id"].(string))
+}
+
+// AddDatabase は、ページ parent の子としてデータベース database を作成し、そのIDを返します
+// APIと異なり database.Id やプロパティ・選択肢のIDが設定されている場合はそれらを使用するため、
+// 実際のワークスペースのデータベースを再現したフィクスチャの作成に使用します（database.Id が設定されていない場合は新しいIDが割り当てられます）
+// 使用されるのは database.Title・Description・Properties・IsInline のみです
+func (s *Server) AddDatabase(parent uuid.UUID, database notion.Database) uuid.UUID {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.lookup(parent.String(), "page"); err != nil {
+		panic(err)
+	}
+	if database.Id == uuid.Nil {
+		database.Id = uuid.New()
+	}
+
+	body := object{}
+	data, err := notionjson.Marshal(map[string]any{
+		"title":       database.Title,
+		"description": database.Description,
+		"properties":  database.Properties,
+		"is_inline":   database.IsInline,
+	})
+	if err != nil {
+		panic(err)
+	}
+	if err := json.Unmarshal(data, &body); err != nil {
+		panic(err)
+	}
+
+	input := objectOf(body["properties"])
+	properties, err := normalizeSchema(object{}, input)
+	if err != nil {
+		panic(err)
+	}
+	for name, v := range input {
+		value := objectOf(v)
+		if newName := stringOf(value["name"]); newName != "" {
+			name = newName
+		}
+		if id := stringOf(value["id"]); id != "" {
+			objectOf(properties[name])["id"] = id
+		}
+	}
+
+	db, err := s.newDatabase(database.Id.String(), object{"type": "page_id", "page_id": parent.String()}, parent.String(), properties, body)
+	if err != nil {
+		panic(err)
+	}
+	s.resolveRollups(objectOf(db["properties"])) // 自身へのリレーションを解決するため、作成後に補完します
+	return database.Id
+}
+
+// AddUser はワークスペースに user を追加します
+// user.Id が設定されていない場合は新しいIDが割り当てられます
+func (s *Server) AddUser(user notion.User) uuid.UUID {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if user.Id == uuid.Nil {
+		user.Id = uuid.New()
+	}
+	data, err := notionjson.Marshal(user)
+	if err != nil {
+		panic(err)
+	}
+	u := object{}
+	if err := json.Unmarshal(data, &u); err != nil {
+		panic(err)
+	}
+	u["object"] = "user"
+	s.users = append(s.users, u)
+	return user.Id
+}
+
+type handlerFunc func(r *http.Request, body object) (any, error)
+
+func (s *Server) handler() http.Handler {
+	mux := http.NewServeMux()
+	handle := func(pattern string, h handlerFunc) {
+		mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+			s.serve(w, r, h)
+		})
+	}
+
+	handle("POST /v1/pages", s.createPage)
+	handle("GET /v1/pages/{id}", s.retrievePage)
+	handle("PATCH /v1/pages/{id}", s.updatePage)
+	handle("GET /v1/pages/{id}/properties/{property_id}", s.retrievePagePropertyItem)
+	handle("POST /v1/databases", s.createDatabase)
+	handle("GET /v1/databases/{id}", s.retrieveDatabase)
+	handle("PATCH /v1/databases/{id}", s.updateDatabase)
+	handle("POST /v1/databases/{id}/query", s.queryDatabase)
+	handle("POST /v1/search", s.search)
+	handle("GET /v1/blocks/{id}", s.retrieveBlock)
+	handle("PATCH /v1/blocks/{id}", s.updateBlock)
+	handle("DELETE /v1/blocks/{id}", s.deleteBlock)
+	handle("GET /v1/blocks/{id}/children", s.retrieveBlockChildren)
+	handle("PATCH /v1/blocks/{id}/children", s.appendBlockChildren)
+	handle("GET /v1/users", s.listUsers)
+	handle("GET /v1/users/me", s.retrieveBotUser)
+	handle("GET /v1/users/{id}", s.retrieveUser)
+	handle("POST /v1/comments", s.createComment)
+	handle("GET /v1/comments", s.listComments)
+	handle("/", func(r *http.Request, body object) (any, error) {
+		return nil, &apiError{http.StatusBadRequest, notion.ErrorCodeInvalidRequestURL, "Invalid request URL."}
+	})
+	return mux
+}
+
+// serve は認証とボディのデコードを行い、h の結果をJSONで書き出します
+func (s *Server) serve(w http.ResponseWriter, r *http.Request, h handlerFunc) {
+	if r.Header.Get("Authorization") != "Bearer "+s.Token {
+		writeError(w, &apiError{http.StatusUnauthorized, notion.ErrorCodeUnauthorized, "API token is invalid."})
+		return
+	}
+	if r.Header.Get("Notion-Version") == "" {
+		writeError(w, &apiError{http.StatusBadRequest, notion.ErrorCodeMissingVersion, "Notion-Version header failed validation: Notion-Version header should be defined, instead was `undefined`."})
+		return
+	}
+
+	body := object{}
+	if data, err := io.ReadAll(r.Body); err != nil {
+		writeError(w, err)
+		return
+	} else if len(data) != 0 {
+		if err := json.Unmarshal(data, &body); err != nil {
+			writeError(w, &apiError{http.StatusBadRequest, notion.ErrorCodeInvalidJSON, "Error parsing JSON body."})
+			return
+		}
+	}
+
+	// 結果のオブジェクトは保持しているものを参照するため、エンコードまでロックします
+	s.mu.Lock()
+	result, err := h(r, body)
+	var data []byte
+	if err == nil {
+		data, err = json.Marshal(result)
+	}
+	s.mu.Unlock()
+
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(data)
+}
+
+// apiError はNotion APIの形式で返されるエラーです
+type apiError struct {
+	status  int
+	code    string
+	message string
+}
+
+func (e *apiError) Error() string {
+	return e.message
+}
+
+func errNotFound(kind string, id string) *apiError {
+	if kind == "" {
+		kind = "block"
+	}
+	return &apiError{http.StatusNotFound, notion.ErrorCodeObjectNotFound, fmt.Sprintf("Could not find %s with ID: %s. Make sure the relevant pages and databases are shared with your integration.", kind, id)}
+}
+
+func errValidation(format string, args ...any) *apiError {
+	return &apiError{http.StatusBadRequest, notion.ErrorCodeValidationError, fmt.Sprintf(format, args...)}
+}
+
+func errArchived() *apiError {
+	return errValidation("Can't edit block that is archived. You must unarchive the block before editing.")
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	apiErr := &apiError{}
+	if !errors.As(err, &apiErr) {
+		apiErr = &apiError{http.StatusInternalServerError, notion.ErrorCodeInternalServerError, err.Error()}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(apiErr.status)
+	json.NewEncoder(w).Encode(object{
+		"object":     "error",
+		"status":     apiErr.status,
+		"code":       apiErr.code,
+		"message":    apiErr.message,
+		"request_id": newId(),
+	})
+}
+
+// pathId はパスパラメータ name をIDとして解釈し、ハイフン付きの形式で返します
+func pathId(r *http.Request, name string) (string, error) {
+	id, err := uuid.Parse(r.PathValue(name))
+	if err != nil {
+		return "", errValidation("path failed validation: path.%s should be a valid uuid, instead was `%q`.", name, r.PathValue(name))
+	}
+	return id.String(), nil
+}
+
+// pageRequest はクエリ文字列またはボディからページネーションのパラメータを取得します
+func pageRequest(r *http.Request, body object) (startCursor string, pageSize int, err error) {
+	startCursor = r.URL.Query().Get("start_cursor")
+	if v, ok := body["start_cursor"].(string); ok {
+		startCursor = v
+	}
+
+	pageSize = 100
+	if v := r.URL.Query().Get("page_size"); v != "" {
+		size, err := strconv.Atoi(v)
+		if err != nil {
+			return "", 0, errValidation("query failed validation: page_size should be a number, instead was `%q`.", v)
+		}
+		pageSize = size
+	}
+	if v, ok := body["page_size"].(float64); ok {
+		pageSize = int(v)
+	}
+	if pageSize < 1 || pageSize > 100 {
+		return "", 0, errValidation("page_size should be ≤ `100` and ≥ `1`, instead was `%d`.", pageSize)
+	}
+	return startCursor, pageSize, nil
+}
+
+// paginate は items を cursors に従ってページに分割し、startCursor から始まるページを返します
+// cursors[i] は items[i] から始まるページのカーソルです
+func paginate(items []object, cursors []string, startCursor string, pageSize int, typ string) (object, error) {
+	start := 0
+	if startCursor != "" {
+		start = slices.Index(cursors, startCursor)
+		if start == -1 {
+			return nil, errValidation("start_cursor provided is invalid: %s", startCursor)
+		}
+	}
+
+	end := min(start+pageSize, len(items))
+	list := object{
+		"object":      "list",
+		"results":     append([]object{}, items[start:end]...),
+		"next_cursor": nil,
+		"has_more":    end < len(items),
+		"type":        typ,
+		typ:           object{},
+	}
+	if end < len(items) {
+		list["next_cursor"] = cursors[end]
+	}
+	return list, nil
+}
+
+// paginateObjects は、IDをカーソルとして items をページに分割します
+func paginateObjects(r *http.Request, body object, items []object, typ string) (object, error) {
+	startCursor, pageSize, err := pageRequest(r, body)
+	if err != nil {
+		return nil, err
+	}
+	cursors := make([]string, len(items))
+	for i, item := range items {
+		cursors[i] = stringOf(item["id"])
+	}
+	return paginate(items, cursors, startCursor, pageSize, typ)
+}
+
+func newId() string {
+	return uuid.NewString()
+}
+
+// timestamp は現在時刻をNotionと同じ形式（分単位）で返します
+func (s *Server) timestamp() string {
+	return s.now().UTC().Truncate(time.Minute).Format("2006-01-02T15:04:05.000Z")
+}
+
+// partialBot はボットユーザーを表す部分的なユーザーオブジェクトを返します
+func (s *Server) partialBot() object {
+	return object{"object": "user", "id": s.botId}
+}
+
+// add は obj を保持し、parentId の子として登録します
+func (s *Server) add(obj object, parentId string) {
+	id := obj["id"].(string)
+	s.objects[id] = obj
+	s.order = append(s.order, id)
+	if parentId != "" {
+		s.children[parentId] = append(s.children[parentId], id)
+	}
+}
+
+// touch は obj の最終更新日時と最終更新者を更新します
+func (s *Server) touch(obj object) {
+	obj["last_edited_time"] = s.timestamp()
+	obj["last_edited_by"] = s.partialBot()
+}
+
+// lookup は id と kind（"page"・"database"・"block"、空の場合は全て）に一致するオブジェクトを返します
+func (s *Server) lookup(id string, kind string) (object, error) {
+	obj, ok := s.objects[id]
+	if !ok || (kind != "" && obj["object"] != kind) {
+		return nil, errNotFound(kind, id)
+	}
+	return obj, nil
+}
+
+// visibleChildren は parentId の子のうち、ゴミ箱に入っていないものを返します
+func (s *Server) visibleChildren(parentId string) []object {
+	children := []object{}
+	for _, id := range s.children[parentId] {
+		if child := s.objects[id]; child["in_trash"] != true {
+			children = append(children, child)
+		}
+	}
+	return children
+}
+
+// setTrash は obj の in_trash と archived を設定します（APIではこの2つは同じ意味を持ちます）
+func setTrash(obj object, body object) {
+	if v, ok := body["in_trash"].(bool); ok {
+		obj["in_trash"], obj["archived"] = v, v
+	}
+	if v, ok := body["archived"].(bool); ok {
+		obj["in_trash"], obj["archived"] = v, v
+	}
+}
+
+// restoring は、body がゴミ箱からの復元を要求しているかを返します
+func restoring(body object) bool {
+	return body["in_trash"] == false || body["archived"] == false
+}
+
+// parseParent は親オブジェクトを正規化し、親の種類とIDを返します
+func parseParent(v any) (parent object, typ string, id string, err error) {
+	m, ok := v.(object)
+	if !ok {
+		return nil, "", "", errValidation("body failed validation: body.parent should be defined, instead was `undefined`.")
+	}
+
+	typ = stringOf(m["type"])
+	if typ == "" {
+		for _, t := range []string{"page_id", "database_id", "block_id", "workspace"} {
+			if _, ok := m[t]; ok {
+				typ = t
+				break
+			}
+		}
+	}
+
+	switch typ {
+	case "workspace":
+		return object{"type": typ, typ: true}, typ, "", nil
+	case "page_id", "database_id", "block_id":
+		parsed, err := uuid.Parse(stringOf(m[typ]))
+		if err != nil {
+			return nil, "", "", errValidation("body failed validation: body.parent.%s should be a valid uuid, instead was `%q`.", typ, stringOf(m[typ]))
+		}
+		return object{"type": typ, typ: parsed.String()}, typ, parsed.String(), nil
+	default:
+		return nil, "", "", errValidation("body failed validation: body.parent should be an object with page_id, database_id or block_id.")
+	}
+}
+
+// typeOf は、"type" フィールドまたは予約されていない唯一のキーからオブジェクトの種類を返します
+// 予約されていないキーが複数ある場合は種類を決められないため、空文字列を返します
+func typeOf(m object, reserved ...string) string {
+	if typ := stringOf(m["type"]); typ != "" {
+		return typ
+	}
+	typ := ""
+	for key := range m {
+		if !slices.Contains(reserved, key) && key != "type" {
+			if typ != "" {
+				return ""
+			}
+			typ = key
+		}
+	}
+	return typ
+}
+
+func stringOf(v any) string {
+	s, _ := v.(string)
+	return s
+}
+
+func objectOf(v any) object {
+	m, _ := v.(object)
+	return m
+}
+
+func arrayOf(v any) []any {
+	a, _ := v.([]any)
+	return a
+}
+
+// decode は保持しているJSONの値 v を notion パッケージの型 out に変換します
+func decode(v any, out any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return notionjson.Unmarshal(data, out)
+}
+
+// plainText はリッチテキストの配列をプレーンテキストに変換します
+func plainText(richTexts any) string {
+	b := strings.Builder{}
+	for _, rt := range arrayOf(richTexts) {
+		b.WriteString(stringOf(objectOf(rt)["plain_text"]))
+	}
+	return b.String()
+}
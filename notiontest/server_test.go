@@ -0,0 +1,367 @@
+package notiontest_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	. "github.com/psyark/notion"
+	"github.com/psyark/notion/notiontest"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServer(t *testing.T) {
+	ctx := context.Background()
+
+	server := notiontest.NewServer()
+	defer server.Close()
+
+	client := server.NewClient()
+	root := server.AddPage("Root")
+
+	var page *Page
+	t.Run("CreatePage", func(t *testing.T) {
+		params := CreatePageParams{}
+		params.Parent(Parent{PageId: root})
+		params.Icon(Emoji{Emoji: "✨"})
+		params.Properties(PropertyValueMap{"title": {Title: NewRichTextArray("生成されたページ")}})
+		params.Children([]Block{{Paragraph: &BlockParagraph{RichText: NewRichTextArray("本文")}}})
+		page = lo.Must(client.CreatePage(ctx, params))
+
+		assert.Equal(t, root, page.Parent.PageId)
+		assert.Equal(t, "生成されたページ", page.Properties["title"].Title.String())
+		assert.Equal(t, "✨", page.Icon.(*Emoji).Emoji)
+
+		got := lo.Must(client.RetrievePage(ctx, page.Id))
+		assert.Equal(t, page.Id, got.Id)
+	})
+
+	t.Run("RetrieveBlockChildren", func(t *testing.T) {
+		pagi := lo.Must(client.RetrieveBlockChildren(ctx, root, RetrieveBlockChildrenParams{}))
+		if assert.Len(t, pagi.Results, 1) {
+			assert.Equal(t, page.Id, pagi.Results[0].Id)
+			assert.Equal(t, "生成されたページ", pagi.Results[0].ChildPage.Title)
+			assert.True(t, pagi.Results[0].HasChildren)
+		}
+
+		pagi = lo.Must(client.RetrieveBlockChildren(ctx, page.Id, RetrieveBlockChildrenParams{}))
+		if assert.Len(t, pagi.Results, 1) {
+			assert.Equal(t, "本文", pagi.Results[0].Paragraph.RichText.String())
+		}
+	})
+
+	var database *Database
+	t.Run("CreateDatabase", func(t *testing.T) {
+		params := CreateDatabaseParams{}
+		params.Parent(Parent{PageId: page.Id})
+		params.Title(NewRichTextArray("生成されたデータベース"))
+		params.Properties(map[string]PropertySchema{
+			"名前":       {Title: &struct{}{}},
+			"数値":       {Number: &PropertySchemaNumber{}},
+			"セレクト":     {Select: &PropertySchemaSelect{Options: []PropertySchemaOption{{Name: "赤", Color: "red"}}}},
+			"チェックボックス": {Checkbox: &struct{}{}},
+			"作成日時":     {CreatedTime: &struct{}{}},
+		})
+		database = lo.Must(client.CreateDatabase(ctx, params))
+
+		assert.Equal(t, "title", database.Properties["名前"].Id)
+		assert.Equal(t, "number", database.Properties["数値"].Number.Format)
+		assert.Equal(t, "red", database.Properties["セレクト"].Select.Options[0].Color)
+		assert.Equal(t, "生成されたデータベース", lo.Must(client.RetrieveDatabase(ctx, database.Id)).Title.String())
+	})
+
+	t.Run("QueryDatabase", func(t *testing.T) {
+		for i := range 3 {
+			params := CreatePageParams{}
+			params.Parent(Parent{DatabaseId: database.Id})
+			params.Properties(PropertyValueMap{
+				"名前":   {Title: NewRichTextArray(fmt.Sprintf("エントリー%d", i))},
+				"数値":   {Number: lo.ToPtr(float64(i))},
+				"セレクト": {Select: &Option{Name: "青"}},
+			})
+			entry := lo.Must(client.CreatePage(ctx, params))
+			assert.Equal(t, "青", entry.Properties["セレクト"].Select.Name)
+			assert.False(t, entry.Properties["チェックボックス"].Checkbox)
+			assert.Equal(t, entry.CreatedTime, entry.Properties["作成日時"].CreatedTime)
+		}
+
+		params := QueryDatabaseParams{}
+		params.PageSize(2)
+		names := []string{}
+		for entry, err := range client.QueryDatabaseAll(ctx, database.Id, params) {
+			if err != nil {
+				t.Fatal(err)
+			}
+			names = append(names, entry.Properties["名前"].Title.String())
+		}
+		assert.Equal(t, []string{"エントリー0", "エントリー1", "エントリー2"}, names)
+
+		params = QueryDatabaseParams{}
+		params.Filter(Filter{Property: "数値", Number: &FilterNumber{GreaterThan: lo.ToPtr(0.0)}})
+		params.Sorts([]Sort{{Property: "数値", Direction: "descending"}})
+		pagi := lo.Must(client.QueryDatabase(ctx, database.Id, params))
+		if assert.Len(t, pagi.Results, 2) {
+			assert.Equal(t, "エントリー2", pagi.Results[0].Properties["名前"].Title.String())
+			assert.Equal(t, "エントリー1", pagi.Results[1].Properties["名前"].Title.String())
+		}
+
+		params = QueryDatabaseParams{}
+		params.Filter(Filter{Property: "数値", Checkbox: &FilterCheckbox{Equals: lo.ToPtr(true)}})
+		_, err := client.QueryDatabase(ctx, database.Id, params)
+		assert.ErrorIs(t, err, ErrValidation)
+
+		db := lo.Must(client.RetrieveDatabase(ctx, database.Id))
+		assert.Len(t, db.Properties["セレクト"].Select.Options, 2)
+	})
+
+	t.Run("UpdatePageProperties", func(t *testing.T) {
+		params := UpdatePagePropertiesParams{}
+		params.Properties(PropertyValueMap{"存在しない": {Checkbox: true}})
+		_, err := client.UpdatePageProperties(ctx, page.Id, params)
+		assert.ErrorIs(t, err, ErrValidation)
+
+		params = UpdatePagePropertiesParams{}
+		params.Properties(PropertyValueMap{"title": {Title: NewRichTextArray("変更されたページ")}})
+		updated := lo.Must(client.UpdatePageProperties(ctx, page.Id, params))
+		assert.Equal(t, "変更されたページ", updated.Properties["title"].Title.String())
+		assert.Equal(t, "✨", updated.Icon.(*Emoji).Emoji)
+	})
+
+	t.Run("RetrievePagePropertyItem", func(t *testing.T) {
+		items := []string{}
+		for item, err := range client.RetrievePagePropertyItemAll(ctx, page.Id, "title", RetrievePagePropertyItemParams{"page_size": 1}) {
+			if err != nil {
+				t.Fatal(err)
+			}
+			items = append(items, item.Title.PlainText)
+		}
+		assert.Equal(t, []string{"変更されたページ"}, items)
+	})
+
+	t.Run("SearchByTitle", func(t *testing.T) {
+		params := SearchByTitleParams{}
+		params.Query("エントリー")
+		pagi := lo.Must(client.SearchByTitle(ctx, params))
+		assert.Len(t, pagi.Results, 3)
+
+		params = SearchByTitleParams{}
+		params.Filter(SearchFilter{Property: "object", Value: "database"})
+		pagi = lo.Must(client.SearchByTitle(ctx, params))
+		if assert.Len(t, pagi.Results, 1) {
+			assert.Equal(t, database.Id, pagi.Results[0].(*Database).Id)
+		}
+	})
+
+	t.Run("Blocks", func(t *testing.T) {
+		params := AppendBlockChildrenParams{}
+		params.Children([]Block{
+			{ToDo: &BlockToDo{RichText: NewRichTextArray("To Do")}},
+			{Heading1: &BlockHeading{RichText: NewRichTextArray("Heading"), IsToggleable: lo.ToPtr(true), Children: []Block{
+				{Paragraph: &BlockParagraph{RichText: NewRichTextArray("nested")}},
+			}}},
+		})
+		pagi := lo.Must(client.AppendBlockChildren(ctx, page.Id, params))
+		if !assert.Len(t, pagi.Results, 2) {
+			return
+		}
+		todo, heading := pagi.Results[0], pagi.Results[1]
+		assert.True(t, heading.HasChildren)
+
+		update := UpdateBlockParams{}
+		update.ToDo(BlockToDo{Checked: lo.ToPtr(true)})
+		block := lo.Must(client.UpdateBlock(ctx, todo.Id, update))
+		assert.Equal(t, "To Do", block.ToDo.RichText.String())
+		assert.True(t, *block.ToDo.Checked)
+
+		lo.Must(client.DeleteBlock(ctx, todo.Id))
+		_, err := client.UpdateBlock(ctx, todo.Id, update)
+		assert.ErrorIs(t, err, ErrValidation)
+
+		children := lo.Must(client.RetrieveBlockChildren(ctx, page.Id, RetrieveBlockChildrenParams{}))
+		assert.Len(t, children.Results, 3) // 本文、データベース、見出し
+
+		restore := UpdateBlockParams{}
+		restore.InTrash(false)
+		assert.False(t, lo.Must(client.UpdateBlock(ctx, todo.Id, restore)).InTrash)
+	})
+
+	t.Run("Limits", func(t *testing.T) {
+		blocks := make([]Block, 101)
+		for i := range blocks {
+			blocks[i] = Block{Divider: &struct{}{}}
+		}
+		params := AppendBlockChildrenParams{}
+		params.Children(blocks)
+		_, err := client.AppendBlockChildren(ctx, page.Id, params)
+		assert.ErrorIs(t, err, ErrValidation)
+
+		params = AppendBlockChildrenParams{}
+		params.Children([]Block{{Paragraph: &BlockParagraph{RichText: NewRichTextArray(strings.Repeat("a", 2001))}}})
+		_, err = client.AppendBlockChildren(ctx, page.Id, params)
+		assert.ErrorIs(t, err, ErrValidation)
+
+		params = AppendBlockChildrenParams{}
+		params.Children([]Block{{BulletedListItem: &BlockBulletedListItem{Children: []Block{{BulletedListItem: &BlockBulletedListItem{Children: []Block{{Divider: &struct{}{}}}}}}}}})
+		_, err = client.AppendBlockChildren(ctx, page.Id, params)
+		assert.ErrorIs(t, err, ErrValidation)
+
+		// 各配列が100以下でも、合計が1000を超える場合はエラーになります
+		toggles := make([]Block, 10)
+		for i := range toggles {
+			toggles[i] = Block{Toggle: &BlockToggle{RichText: NewRichTextArray("toggle"), Children: blocks[:100]}}
+		}
+		params = AppendBlockChildrenParams{}
+		params.Children(toggles)
+		_, err = client.AppendBlockChildren(ctx, page.Id, params)
+		assert.ErrorIs(t, err, ErrValidation)
+	})
+
+	t.Run("AppendBlocksAtomically", func(t *testing.T) {
+		count := func() int {
+			return len(lo.Must(client.RetrieveBlockChildren(ctx, page.Id, RetrieveBlockChildrenParams{})).Results)
+		}
+		before := count()
+
+		// 不正なブロックが含まれる場合、それより前のブロックも追加されません
+		params := AppendBlockChildrenParams{}
+		params.Children([]Block{
+			NewParagraph("valid"),
+			NewToggle("toggle"),
+			{Toggle: &BlockToggle{Children: []Block{NewParagraph(strings.Repeat("a", 2001))}}},
+		})
+		_, err := client.AppendBlockChildren(ctx, page.Id, params)
+		assert.ErrorIs(t, err, ErrValidation)
+		assert.Equal(t, before, count())
+
+		// type が無く、種類を決められないブロック
+		ambiguous := func(next Handler) Handler {
+			return func(ctx context.Context, req *Request) (any, error) {
+				req.Body = []byte(`{"children":[{"paragraph":{"rich_text":[]},"quote":{"rich_text":[]}}]}`)
+				return next(ctx, req)
+			}
+		}
+		_, err = client.AppendBlockChildren(ctx, page.Id, AppendBlockChildrenParams{}, WithMiddleware(ambiguous))
+		assert.ErrorIs(t, err, ErrValidation)
+		assert.Equal(t, before, count())
+
+		// 子ブロックが不正な場合、ページも作成されません
+		create := CreatePageParams{}
+		create.Parent(Parent{PageId: root})
+		create.Properties(PropertyValueMap{"title": {Title: NewRichTextArray("不正なページ")}})
+		create.Children([]Block{NewParagraph(strings.Repeat("a", 2001))})
+		_, err = client.CreatePage(ctx, create)
+		assert.ErrorIs(t, err, ErrValidation)
+		assert.Len(t, lo.Must(client.RetrieveBlockChildren(ctx, root, RetrieveBlockChildrenParams{})).Results, 1)
+	})
+
+	t.Run("Trash", func(t *testing.T) {
+		params := UpdatePagePropertiesParams{}
+		params.InTrash(true)
+		lo.Must(client.UpdatePageProperties(ctx, page.Id, params))
+
+		children := lo.Must(client.RetrieveBlockChildren(ctx, root, RetrieveBlockChildrenParams{}))
+		assert.Empty(t, children.Results)
+
+		params = UpdatePagePropertiesParams{}
+		params.Icon(Emoji{Emoji: "🍣"})
+		_, err := client.UpdatePageProperties(ctx, page.Id, params)
+		assert.ErrorIs(t, err, ErrValidation)
+
+		params = UpdatePagePropertiesParams{}
+		params.InTrash(false)
+		assert.False(t, lo.Must(client.UpdatePageProperties(ctx, page.Id, params)).InTrash)
+	})
+
+	t.Run("Users", func(t *testing.T) {
+		userId := server.AddUser(User{Name: "Person", Person: &UserPerson{Email: "person@example.com"}})
+
+		bot := lo.Must(client.RetrieveBotUser(ctx))
+		assert.NotNil(t, bot.Bot)
+
+		ids := []uuid.UUID{}
+		for user, err := range client.ListUsersAll(ctx, ListUsersParams{"page_size": 1}) {
+			if err != nil {
+				t.Fatal(err)
+			}
+			ids = append(ids, user.Id)
+		}
+		assert.Equal(t, []uuid.UUID{bot.Id, userId}, ids)
+		assert.Equal(t, "Person", lo.Must(client.RetrieveUser(ctx, userId)).Name)
+	})
+
+	t.Run("Comments", func(t *testing.T) {
+		params := CreateCommentParams{}
+		params.Parent(Parent{PageId: page.Id})
+		params.RichText(NewRichTextArray("コメント"))
+		comment := lo.Must(client.CreateComment(ctx, params))
+
+		params = CreateCommentParams{}
+		params.DiscussionId(comment.DiscussionId)
+		params.RichText(NewRichTextArray("返信"))
+		lo.Must(client.CreateComment(ctx, params))
+
+		list := ListCommentsParams{}
+		list.BlockId(page.Id)
+		pagi := lo.Must(client.ListComments(ctx, list))
+		if assert.Len(t, pagi.Results, 2) {
+			assert.Equal(t, "返信", pagi.Results[1].RichText.String())
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		_, err := client.RetrievePage(ctx, uuid.New())
+		assert.ErrorIs(t, err, ErrNotFound)
+
+		_, err = client.RetrieveDatabase(ctx, page.Id)
+		assert.ErrorIs(t, err, ErrNotFound)
+
+		_, err = NewClient("invalid", WithBaseURL(server.URL)).RetrieveBotUser(ctx)
+		assert.ErrorIs(t, err, ErrUnauthorized)
+
+		params := QueryDatabaseParams{}
+		params.StartCursor("invalid")
+		_, err = client.QueryDatabase(ctx, database.Id, params)
+		assert.ErrorIs(t, err, ErrValidation)
+	})
+}
+
+func TestAddDatabase(t *testing.T) {
+	ctx := context.Background()
+
+	server := notiontest.NewServer()
+	defer server.Close()
+
+	client := server.NewClient()
+	root := server.AddPage("Root")
+
+	// 指定したIDが維持され、自身へのリレーションを集計するロールアップも解決されます
+	id := uuid.New()
+	server.AddDatabase(root, Database{
+		Id:    id,
+		Title: NewRichTextArray("フィクスチャ"),
+		Properties: map[string]Property{
+			"名前":     {Title: &struct{}{}},
+			"数値":     {Id: "wSuU", Number: &PropertyNumber{Format: "yen"}},
+			"ステータス":  {Id: "~_pB", Status: &PropertyStatus{Options: []OptionDescription{{Id: "e06fafe8-bf2e-4911-a125-d49ed84dfb54", Name: "Not started"}}}},
+			"リレーション": {Id: "kOoD", Relation: &PropertyRelation{DatabaseId: id, SingleProperty: &struct{}{}}},
+			"ロールアップ": {Id: "lxhZ", Rollup: &PropertyRollup{RelationPropertyName: "リレーション", RollupPropertyName: "名前", Function: "show_original"}},
+		},
+	})
+
+	got := lo.Must(client.RetrieveDatabase(ctx, id))
+	assert.Equal(t, "フィクスチャ", got.Title.String())
+	assert.Equal(t, "title", got.Properties["名前"].Id)
+	assert.Equal(t, "wSuU", got.Properties["数値"].Id)
+	assert.Equal(t, "e06fafe8-bf2e-4911-a125-d49ed84dfb54", got.Properties["ステータス"].Status.Options[0].Id)
+	assert.Equal(t, "kOoD", got.Properties["ロールアップ"].Rollup.RelationPropertyId)
+	assert.Equal(t, "title", got.Properties["ロールアップ"].Rollup.RollupPropertyId)
+
+	params := CreatePageParams{}
+	params.Parent(Parent{DatabaseId: id})
+	params.Properties(PropertyValueMap{"ステータス": {Status: &Option{Name: "Not started"}}})
+	created := lo.Must(client.CreatePage(ctx, params))
+	assert.Equal(t, "e06fafe8-bf2e-4911-a125-d49ed84dfb54", created.Properties["ステータス"].Status.Id)
+}
@@ -0,0 +1,103 @@
+package notiontest
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+func (s *Server) listUsers(r *http.Request, body object) (any, error) {
+	return paginateObjects(r, body, s.users, "user")
+}
+
+func (s *Server) retrieveUser(r *http.Request, body object) (any, error) {
+	id, err := pathId(r, "id")
+	if err != nil {
+		return nil, err
+	}
+	for _, user := range s.users {
+		if user["id"] == id {
+			return user, nil
+		}
+	}
+	return nil, errNotFound("user", id)
+}
+
+func (s *Server) retrieveBotUser(r *http.Request, body object) (any, error) {
+	return s.users[0], nil
+}
+
+func (s *Server) createComment(r *http.Request, body object) (any, error) {
+	richText, err := normalizeRichTexts(body["rich_text"], "rich_text")
+	if err != nil {
+		return nil, err
+	}
+
+	var parent object
+	var discussionId string
+	switch {
+	case body["discussion_id"] != nil:
+		id, err := uuid.Parse(stringOf(body["discussion_id"]))
+		if err != nil {
+			return nil, errValidation("body failed validation: body.discussion_id should be a valid uuid, instead was `%q`.", stringOf(body["discussion_id"]))
+		}
+		discussionId = id.String()
+		for _, comment := range s.comments {
+			if comment["discussion_id"] == discussionId {
+				parent = objectOf(comment["parent"])
+			}
+		}
+		if parent == nil {
+			return nil, errNotFound("discussion", discussionId)
+		}
+	case body["parent"] != nil:
+		p, parentType, parentId, err := parseParent(body["parent"])
+		if err != nil {
+			return nil, err
+		}
+		if parentType != "page_id" {
+			return nil, errValidation("body failed validation: body.parent.type should be page_id, instead was `%q`.", parentType)
+		}
+		if _, err := s.lookup(parentId, "page"); err != nil {
+			return nil, err
+		}
+		parent = p
+		discussionId = newId()
+	default:
+		return nil, errValidation("body failed validation. Fix one: body.parent should be defined, instead was `undefined`. body.discussion_id should be defined, instead was `undefined`.")
+	}
+
+	ts := s.timestamp()
+	comment := object{
+		"object":           "comment",
+		"id":               newId(),
+		"parent":           parent,
+		"discussion_id":    discussionId,
+		"created_time":     ts,
+		"last_edited_time": ts,
+		"created_by":       s.partialBot(),
+		"rich_text":        richText,
+	}
+	s.comments = append(s.comments, comment)
+	return comment, nil
+}
+
+func (s *Server) listComments(r *http.Request, body object) (any, error) {
+	blockId := r.URL.Query().Get("block_id")
+	id, err := uuid.Parse(blockId)
+	if err != nil {
+		return nil, errValidation("query failed validation: block_id should be a valid uuid, instead was `%q`.", blockId)
+	}
+	if _, err := s.lookup(id.String(), ""); err != nil {
+		return nil, err
+	}
+
+	comments := []object{}
+	for _, comment := range s.comments {
+		parent := objectOf(comment["parent"])
+		if parent["page_id"] == id.String() || parent["block_id"] == id.String() {
+			comments = append(comments, comment)
+		}
+	}
+	return paginateObjects(r, body, comments, "comment")
+}
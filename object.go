@@ -38,18 +38,6 @@ type PageReference struct {
 	Id uuid.UUID `json:"id"`
 }
 
-// https://developers.notion.com/reference/errors
-type Error struct {
-	Object  string `json:"object"`
-	Status  int    `json:"status"`
-	Code    string `json:"code"`
-	Message string `json:"message"`
-}
-
-func (e Error) Error() string {
-	return fmt.Sprintf("(%v) %v", e.Code, e.Message)
-}
-
 func defined(fieldValue any) bool {
 	v := reflect.ValueOf(fieldValue)
 
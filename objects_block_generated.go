@@ -26,6 +26,7 @@ type Block struct {
 	Archived         bool                   `json:"archived"`                   // The archived status of the block.
 	InTrash          bool                   `json:"in_trash"`                   // Whether the block has been deleted.
 	HasChildren      bool                   `json:"has_children"`               // Whether or not the block has children blocks nested within it.
+	UnknownPayload   json.RawMessage        `json:"-"`                          // The payload of a block type not known to this library, such as "unsupported". It is kept so that the block round-trips through MarshalJSON.
 	Bookmark         *BlockBookmark         `json:"bookmark"`                   // Bookmark
 	Breadcrumb       *struct{}              `json:"breadcrumb"`                 // Breadcrumb block objects do not contain any information within the breadcrumb property.
 	BulletedListItem *BlockBulletedListItem `json:"bulleted_list_item"`         // Bulleted list item
@@ -33,8 +34,8 @@ type Block struct {
 	ChildDatabase    *BlockChildDatabase    `json:"child_database"`             // Child database
 	ChildPage        *BlockChildPage        `json:"child_page"`                 // Child page
 	Code             *BlockCode             `json:"code"`                       // Code
-	ColumnList       *struct{}              `json:"column_list"`                // Column lists are parent blocks for columns. They do not contain any information within the column_list property.
-	Column           *struct{}              `json:"column"`                     // Columns are parent blocks for any block types listed in this reference except for other columns. They do not contain any information within the column property. They can only be appended to column_lists.
+	ColumnList       *BlockColumnList       `json:"column_list"`                // Column lists are parent blocks for columns. They do not contain any information within the column_list property.
+	Column           *BlockColumn           `json:"column"`                     // Columns are parent blocks for any block types listed in this reference except for other columns. They do not contain any information within the column property. They can only be appended to column_lists.
 	Divider          *struct{}              `json:"divider"`                    // Divider block objects do not contain any information within the divider property.
 	Embed            *BlockEmbed            `json:"embed"`                      // Embed
 	Equation         *BlockEquation         `json:"equation"`                   // Equation
@@ -42,12 +43,22 @@ type Block struct {
 	Heading1         *BlockHeading          `json:"heading_1"`
 	Heading2         *BlockHeading          `json:"heading_2"`
 	Heading3         *BlockHeading          `json:"heading_3"`
-	Image            *File                  `json:"image"`        // Image block objects contain a file object detailing information about the image.
-	LinkPreview      *BlockLinkPreview      `json:"link_preview"` // Link Preview block objects contain the originally pasted url:
-	Paragraph        *BlockParagraph        `json:"paragraph"`    // Paragraph
-	Pdf              *BlockPdf              `json:"pdf"`          // PDF
-	SyncedBlock      *BlockSyncedBlock      `json:"synced_block"` // Synced block
-	ToDo             *BlockToDo             `json:"to_do"`        // To do
+	Image            *File                  `json:"image"`              // Image block objects contain a file object detailing information about the image.
+	LinkPreview      *BlockLinkPreview      `json:"link_preview"`       // Link Preview block objects contain the originally pasted url:
+	LinkToPage       *BlockLinkToPage       `json:"link_to_page"`       // UNDOCUMENTED
+	NumberedListItem *BlockNumberedListItem `json:"numbered_list_item"` // Numbered list item
+	Paragraph        *BlockParagraph        `json:"paragraph"`          // Paragraph
+	Pdf              *BlockPdf              `json:"pdf"`                // PDF
+	Quote            *BlockQuote            `json:"quote"`              // Quote
+	SyncedBlock      *BlockSyncedBlock      `json:"synced_block"`       // Synced block
+	Table            *BlockTable            `json:"table"`              // Table
+	TableRow         *BlockTableRow         `json:"table_row"`          // Table rows
+	TableOfContents  *BlockTableOfContents  `json:"table_of_contents"`  // Table of contents
+	Template         *BlockTemplate         `json:"template"`           // Template
+	ToDo             *BlockToDo             `json:"to_do"`              // To do
+	Toggle           *BlockToggle           `json:"toggle"`             // Toggle blocks
+	Video            *File                  `json:"video"`              // Video block objects contain a file object detailing information about the image.
+	Audio            *File                  `json:"audio"`              // UNDOCUMENTED
 }
 
 func (o Block) MarshalJSON() ([]byte, error) {
@@ -89,14 +100,34 @@ func (o Block) MarshalJSON() ([]byte, error) {
 			o.Type = "image"
 		case defined(o.LinkPreview):
 			o.Type = "link_preview"
+		case defined(o.LinkToPage):
+			o.Type = "link_to_page"
+		case defined(o.NumberedListItem):
+			o.Type = "numbered_list_item"
 		case defined(o.Paragraph):
 			o.Type = "paragraph"
 		case defined(o.Pdf):
 			o.Type = "pdf"
+		case defined(o.Quote):
+			o.Type = "quote"
 		case defined(o.SyncedBlock):
 			o.Type = "synced_block"
+		case defined(o.Table):
+			o.Type = "table"
+		case defined(o.TableRow):
+			o.Type = "table_row"
+		case defined(o.TableOfContents):
+			o.Type = "table_of_contents"
+		case defined(o.Template):
+			o.Type = "template"
 		case defined(o.ToDo):
 			o.Type = "to_do"
+		case defined(o.Toggle):
+			o.Type = "toggle"
+		case defined(o.Video):
+			o.Type = "video"
+		case defined(o.Audio):
+			o.Type = "audio"
 		}
 	}
 	type Alias Block
@@ -105,6 +136,7 @@ func (o Block) MarshalJSON() ([]byte, error) {
 		return nil, err
 	}
 	visibility := map[string]bool{
+		"audio":              o.Type == "audio",
 		"bookmark":           o.Type == "bookmark",
 		"breadcrumb":         o.Type == "breadcrumb",
 		"bulleted_list_item": o.Type == "bulleted_list_item",
@@ -123,33 +155,65 @@ func (o Block) MarshalJSON() ([]byte, error) {
 		"heading_3":          o.Type == "heading_3",
 		"image":              o.Type == "image",
 		"link_preview":       o.Type == "link_preview",
+		"link_to_page":       o.Type == "link_to_page",
+		"numbered_list_item": o.Type == "numbered_list_item",
 		"paragraph":          o.Type == "paragraph",
 		"pdf":                o.Type == "pdf",
+		"quote":              o.Type == "quote",
 		"synced_block":       o.Type == "synced_block",
+		"table":              o.Type == "table",
+		"table_of_contents":  o.Type == "table_of_contents",
+		"table_row":          o.Type == "table_row",
+		"template":           o.Type == "template",
 		"to_do":              o.Type == "to_do",
+		"toggle":             o.Type == "toggle",
+		"video":              o.Type == "video",
 	}
-	return omitFields(data, visibility)
+	data, err = omitFields(data, visibility)
+	if err != nil || o.UnknownPayload == nil {
+		return data, err
+	}
+	return setField(data, o.Type, o.UnknownPayload)
+}
+
+// UnmarshalJSON keeps the payload of an unknown type in UnknownPayload
+func (o *Block) UnmarshalJSON(data []byte) error {
+	type Alias Block
+	if err := json.Unmarshal(data, (*Alias)(o)); err != nil {
+		return fmt.Errorf("unmarshaling Block: %w", err)
+	}
+	o.UnknownPayload = nil
+	switch o.Type {
+	case "bookmark", "breadcrumb", "bulleted_list_item", "callout", "child_database", "child_page", "code", "column_list", "column", "divider", "embed", "equation", "file", "heading_1", "heading_2", "heading_3", "image", "link_preview", "link_to_page", "numbered_list_item", "paragraph", "pdf", "quote", "synced_block", "table", "table_row", "table_of_contents", "template", "to_do", "toggle", "video", "audio":
+	default:
+		payload, err := getField(data, o.Type)
+		if err != nil {
+			return fmt.Errorf("unmarshaling Block: %w", err)
+		}
+		o.UnknownPayload = payload
+	}
+	return nil
 }
 
 // Bookmark
 type BlockBookmark struct {
-	Caption RichTextArray `json:"caption"` // The caption for the bookmark.
-	Url     string        `json:"url"`     // The link for the bookmark.
+	Caption RichTextArray `json:"caption,omitempty"` // The caption for the bookmark.
+	Url     string        `json:"url,omitempty"`     // The link for the bookmark.
 }
 
 // Bulleted list item
 type BlockBulletedListItem struct {
-	RichText RichTextArray `json:"rich_text"`          // The rich text in the bulleted_list_item block.
-	Color    string        `json:"color,omitempty"`    // The color of the block. Possible values are: - "blue" - "blue_background" - "brown" - "brown_background" - "default" - "gray" - "gray_background" - "green" - "green_background" - "orange" - "orange_background" - "yellow" - "green" - "pink" - "pink_background" - "purple" - "purple_background" - "red" - "red_background" - "yellow_background"
-	Children []Block       `json:"children,omitempty"` // The nested child blocks (if any) of the bulleted_list_item block.
+	RichText RichTextArray `json:"rich_text,omitempty"` // The rich text in the bulleted_list_item block.
+	Color    string        `json:"color,omitempty"`     // The color of the block. Possible values are: - "blue" - "blue_background" - "brown" - "brown_background" - "default" - "gray" - "gray_background" - "green" - "green_background" - "orange" - "orange_background" - "yellow" - "green" - "pink" - "pink_background" - "purple" - "purple_background" - "red" - "red_background" - "yellow_background"
+	Children []Block       `json:"children,omitempty"`  // The nested child blocks (if any) of the bulleted_list_item block.
 }
 
 // Callout
 type BlockCallout struct {
-	RichText RichTextArray `json:"rich_text"`          // The rich text in the callout block.
-	Icon     FileOrEmoji   `json:"icon,omitempty"`     // An emoji or file object that represents the callout's icon. If the callout does not have an icon.
-	Color    string        `json:"color,omitempty"`    // The color of the block. Possible values are: - "blue" - "blue_background" - "brown" - "brown_background" - "default" - "gray" - "gray_background" - "green" - "green_background" - "orange" - "orange_background" - "yellow" - "green" - "pink" - "pink_background" - "purple" - "purple_background" - "red" - "red_background" - "yellow_background"
-	Children []Block       `json:"children,omitempty"` // UNDOCUMENTED
+	RichText RichTextArray `json:"rich_text,omitempty"` // The rich text in the callout block.
+	Icon     FileOrEmoji   `json:"icon,omitempty"`      // An emoji or file object that represents the callout's icon. If the callout does not have an icon.
+	Color    string        `json:"color,omitempty"`     // The color of the block. Possible values are: - "blue" - "blue_background" - "brown" - "brown_background" - "default" - "gray" - "gray_background" - "green" - "green_background" - "orange" - "orange_background" - "yellow" - "green" - "pink" - "pink_background" - "purple" - "purple_background" - "red" - "red_background" - "yellow_background"
+	Children []Block       `json:"children,omitempty"`  // UNDOCUMENTED
 }
 
 // UnmarshalJSON assigns the appropriate implementation to interface field(s)
@@ -178,27 +242,37 @@ type BlockChildPage struct {
 
 // Code
 type BlockCode struct {
-	Caption  RichTextArray `json:"caption,omitempty"` // The rich text in the caption of the code block.
-	RichText RichTextArray `json:"rich_text"`         // The rich text in the code block.
-	Language string        `json:"language"`          // The language of the code contained in the code block.
+	Caption  RichTextArray `json:"caption,omitempty"`   // The rich text in the caption of the code block.
+	RichText RichTextArray `json:"rich_text,omitempty"` // The rich text in the code block.
+	Language string        `json:"language,omitempty"`  // The language of the code contained in the code block.
+}
+
+// Column lists are parent blocks for columns. They do not contain any information within the column_list property.
+type BlockColumnList struct {
+	Children []Block `json:"children,omitempty"` // UNDOCUMENTED
+}
+
+// Columns are parent blocks for any block types listed in this reference except for other columns. They do not contain any information within the column property. They can only be appended to column_lists.
+type BlockColumn struct {
+	Children []Block `json:"children,omitempty"` // UNDOCUMENTED
 }
 
 // Embed
 type BlockEmbed struct {
-	Url string `json:"url"` // The link to the website that the embed block displays.
+	Url string `json:"url,omitempty"` // The link to the website that the embed block displays.
 }
 
 // Equation
 type BlockEquation struct {
-	Expression string `json:"expression"` // A KaTeX compatible string.
+	Expression string `json:"expression,omitempty"` // A KaTeX compatible string.
 }
 
 // All heading block objects, heading_1, heading_2, and heading_3, contain the following information within their corresponding objects:
 type BlockHeading struct {
-	RichText     RichTextArray `json:"rich_text"`          // The rich text of the heading.
-	Color        string        `json:"color,omitempty"`    // The color of the block. Possible values are: - "blue" - "blue_background" - "brown" - "brown_background" - "default" - "gray" - "gray_background" - "green" - "green_background" - "orange" - "orange_background" - "yellow" - "green" - "pink" - "pink_background" - "purple" - "purple_background" - "red" - "red_background" - "yellow_background"
-	IsToggleable bool          `json:"is_toggleable"`      // Whether or not the heading block is a toggle heading or not. If true, then the heading block toggles and can support children. If false, then the heading block is a static heading block.
-	Children     []Block       `json:"children,omitempty"` // UNDOCUMENTED
+	RichText     RichTextArray `json:"rich_text,omitempty"`     // The rich text of the heading.
+	Color        string        `json:"color,omitempty"`         // The color of the block. Possible values are: - "blue" - "blue_background" - "brown" - "brown_background" - "default" - "gray" - "gray_background" - "green" - "green_background" - "orange" - "orange_background" - "yellow" - "green" - "pink" - "pink_background" - "purple" - "purple_background" - "red" - "red_background" - "yellow_background"
+	IsToggleable *bool         `json:"is_toggleable,omitempty"` // Whether or not the heading block is a toggle heading or not. If true, then the heading block toggles and can support children. If false, then the heading block is a static heading block.
+	Children     []Block       `json:"children,omitempty"`      // UNDOCUMENTED
 }
 
 // Link Preview block objects contain the originally pasted url:
@@ -206,11 +280,46 @@ type BlockLinkPreview struct {
 	Url string `json:"url"`
 }
 
+// UNDOCUMENTED
+type BlockLinkToPage struct {
+	Type       string    `json:"type"`
+	PageId     uuid.UUID `json:"page_id"`
+	DatabaseId uuid.UUID `json:"database_id"`
+}
+
+func (o BlockLinkToPage) MarshalJSON() ([]byte, error) {
+	if o.Type == "" {
+		switch {
+		case defined(o.PageId):
+			o.Type = "page_id"
+		case defined(o.DatabaseId):
+			o.Type = "database_id"
+		}
+	}
+	type Alias BlockLinkToPage
+	data, err := json.Marshal(Alias(o))
+	if err != nil {
+		return nil, err
+	}
+	visibility := map[string]bool{
+		"database_id": o.Type == "database_id",
+		"page_id":     o.Type == "page_id",
+	}
+	return omitFields(data, visibility)
+}
+
+// Numbered list item
+type BlockNumberedListItem struct {
+	RichText RichTextArray `json:"rich_text,omitempty"` // The rich text displayed in the numbered_list_item block.
+	Color    string        `json:"color,omitempty"`     // The color of the block. Possible values are: - "blue" - "blue_background" - "brown" - "brown_background" - "default" - "gray" - "gray_background" - "green" - "green_background" - "orange" - "orange_background" - "yellow" - "green" - "pink" - "pink_background" - "purple" - "purple_background" - "red" - "red_background" - "yellow_background"
+	Children []Block       `json:"children,omitempty"`  // The nested child blocks (if any) of the numbered_list_item block.
+}
+
 // Paragraph
 type BlockParagraph struct {
-	RichText RichTextArray `json:"rich_text"`          // The rich text displayed in the paragraph block.
-	Color    string        `json:"color,omitempty"`    // The color of the block. Possible values are: - "blue" - "blue_background" - "brown" - "brown_background" - "default" - "gray" - "gray_background" - "green" - "green_background" - "orange" - "orange_background" - "yellow" - "green" - "pink" - "pink_background" - "purple" - "purple_background" - "red" - "red_background" - "yellow_background"
-	Children []Block       `json:"children,omitempty"` // The nested child blocks (if any) of the paragraph block.
+	RichText RichTextArray `json:"rich_text,omitempty"` // The rich text displayed in the paragraph block.
+	Color    string        `json:"color,omitempty"`     // The color of the block. Possible values are: - "blue" - "blue_background" - "brown" - "brown_background" - "default" - "gray" - "gray_background" - "green" - "green_background" - "orange" - "orange_background" - "yellow" - "green" - "pink" - "pink_background" - "purple" - "purple_background" - "red" - "red_background" - "yellow_background"
+	Children []Block       `json:"children,omitempty"`  // The nested child blocks (if any) of the paragraph block.
 }
 
 /*
@@ -219,12 +328,19 @@ PDF
 A PDF block object represents a PDF that has been embedded within a Notion page. It contains the following fields:
 */
 type BlockPdf struct {
-	Caption  RichTextArray `json:"caption"`            // A caption, if provided, for the PDF block.
-	Type     string        `json:"type"`               // A constant string representing the type of PDF. file indicates a Notion-hosted file, and external represents a third-party link.
+	Caption  RichTextArray `json:"caption,omitempty"`  // A caption, if provided, for the PDF block.
+	Type     string        `json:"type,omitempty"`     // A constant string representing the type of PDF. file indicates a Notion-hosted file, and external represents a third-party link.
 	External *FileExternal `json:"external,omitempty"` // An object containing type-specific information about the PDF.
 	File     *FileFile     `json:"file,omitempty"`     // An object containing type-specific information about the PDF.
 }
 
+// Quote
+type BlockQuote struct {
+	RichText RichTextArray `json:"rich_text,omitempty"` // The rich text displayed in the quote block.
+	Color    string        `json:"color,omitempty"`     // The color of the block. Possible values are: - "blue" - "blue_background" - "brown" - "brown_background" - "default" - "gray" - "gray_background" - "green" - "green_background" - "orange" - "orange_background" - "yellow" - "green" - "pink" - "pink_background" - "purple" - "purple_background" - "red" - "red_background" - "yellow_background"
+	Children []Block       `json:"children,omitempty"`  // The nested child blocks, if any, of the quote block.
+}
+
 /*
 Synced block
 
@@ -243,10 +359,45 @@ type SyncedFrom struct {
 	BlockId uuid.UUID `json:"block_id"` // An identifier for the original synced_block.
 }
 
+// Table
+type BlockTable struct {
+	TableWidth      int     `json:"table_width,omitempty"`       // The number of columns in the table. Note that this cannot be changed via the public API once a table is created.
+	HasColumnHeader *bool   `json:"has_column_header,omitempty"` // Whether the table has a column header. If true, then the first row in the table appears visually distinct from the other rows.
+	HasRowHeader    *bool   `json:"has_row_header,omitempty"`    // Whether the table has a header row. If true, then the first column in the table appears visually distinct from the other columns.
+	Children        []Block `json:"children,omitempty"`          // UNDOCUMENTED
+}
+
+// Table rows
+type BlockTableRow struct {
+	Cells []RichTextArray `json:"cells,omitempty"` // An array of cell contents in horizontal display order. Each cell is an array of rich text objects.
+}
+
+// Table of contents
+type BlockTableOfContents struct {
+	Color string `json:"color,omitempty"` // The color of the block. Possible values are: - "blue" - "blue_background" - "brown" - "brown_background" - "default" - "gray" - "gray_background" - "green" - "green_background" - "orange" - "orange_background" - "yellow" - "green" - "pink" - "pink_background" - "purple" - "purple_background" - "red" - "red_background" - "yellow_background"
+}
+
+/*
+Template
+
+Template blocks represent template buttons in the Notion UI.
+*/
+type BlockTemplate struct {
+	RichText RichTextArray `json:"rich_text"`          // The rich text displayed in the title of the template.
+	Children []Block       `json:"children,omitempty"` // The nested child blocks, if any, of the template block. These blocks are duplicated when the template block is used in the UI.
+}
+
 // To do
 type BlockToDo struct {
-	RichText RichTextArray `json:"rich_text"`          // The rich text displayed in the To do block.
-	Checked  *bool         `json:"checked,omitempty"`  // Whether the To do is checked.
-	Color    string        `json:"color,omitempty"`    // The color of the block. Possible values are: - "blue" - "blue_background" - "brown" - "brown_background" - "default" - "gray" - "gray_background" - "green" - "green_background" - "orange" - "orange_background" - "yellow" - "green" - "pink" - "pink_background" - "purple" - "purple_background" - "red" - "red_background" - "yellow_background"
-	Children []Block       `json:"children,omitempty"` // The nested child blocks, if any, of the To do block.
+	RichText RichTextArray `json:"rich_text,omitempty"` // The rich text displayed in the To do block.
+	Checked  *bool         `json:"checked,omitempty"`   // Whether the To do is checked.
+	Color    string        `json:"color,omitempty"`     // The color of the block. Possible values are: - "blue" - "blue_background" - "brown" - "brown_background" - "default" - "gray" - "gray_background" - "green" - "green_background" - "orange" - "orange_background" - "yellow" - "green" - "pink" - "pink_background" - "purple" - "purple_background" - "red" - "red_background" - "yellow_background"
+	Children []Block       `json:"children,omitempty"`  // The nested child blocks, if any, of the To do block.
+}
+
+// Toggle blocks
+type BlockToggle struct {
+	RichText RichTextArray `json:"rich_text,omitempty"` // The rich text displayed in the Toggle block.
+	Color    string        `json:"color,omitempty"`     // The color of the block. Possible values are: - "blue" - "blue_background" - "brown" - "brown_background" - "default" - "gray" - "gray_background" - "green" - "green_background" - "orange" - "orange_background" - "yellow" - "green" - "pink" - "pink_background" - "purple" - "purple_background" - "red" - "red_background" - "yellow_background"
+	Children []Block       `json:"children,omitempty"`  // The nested child blocks, if any, of the Toggle block.
 }
@@ -110,6 +110,12 @@ func (s alwaysBlock) MarshalJSON() ([]byte, error) {
 	return []byte("\"block\""), nil
 }
 
+type alwaysComment string
+
+func (s alwaysComment) MarshalJSON() ([]byte, error) {
+	return []byte("\"comment\""), nil
+}
+
 type alwaysDatabase string
 
 func (s alwaysDatabase) MarshalJSON() ([]byte, error) {
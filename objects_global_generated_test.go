@@ -23,6 +23,15 @@ func TestBotUserDataOwner_unmarshal(t *testing.T) {
 	}
 }
 
+func TestComment_unmarshal(t *testing.T) {
+	tests := []string{
+		"{\n  \"object\": \"comment\",\n  \"id\": \"7a793800-3e55-4d5e-8009-2261de026179\",\n  \"parent\": {\n    \"type\": \"page_id\",\n    \"page_id\": \"5c6a2821-6bb1-4a7e-b6e1-c50111515c3d\"\n  },\n  \"discussion_id\": \"f4be6752-a539-4da2-a8a9-c3953e13bc0b\",\n  \"created_time\": \"2022-07-15T21:17:00.000Z\",\n  \"last_edited_time\": \"2022-07-15T21:17:00.000Z\",\n  \"created_by\": {\n    \"object\": \"user\",\n    \"id\": \"e450a39e-9051-4d36-bc4e-8581611fc592\"\n  },\n  \"rich_text\": [\n    {\n      \"type\": \"text\",\n      \"text\": {\n        \"content\": \"Hello world\",\n        \"link\": null\n      },\n      \"annotations\": {\n        \"bold\": false,\n        \"italic\": false,\n        \"strikethrough\": false,\n        \"underline\": false,\n        \"code\": false,\n        \"color\": \"default\"\n      },\n      \"plain_text\": \"Hello world\",\n      \"href\": null\n    }\n  ]\n}",
+	}
+	for _, wantStr := range tests {
+		checkUnmarshal[Comment](t, wantStr)
+	}
+}
+
 func TestEmoji_unmarshal(t *testing.T) {
 	tests := []string{
 		"{\n  \"type\": \"emoji\",\n  \"emoji\": \"😻\"\n}",
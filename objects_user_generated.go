@@ -21,12 +21,13 @@ User objects will always contain object and id keys, as described below. The rem
 */
 type User struct {
 	Type      string      `json:"type,omitempty"`
-	Object    alwaysUser  `json:"object"`     // Always "user"
-	Id        uuid.UUID   `json:"id"`         // Unique identifier for this user.
-	Name      string      `json:"name"`       // User's name, as displayed in Notion.
-	AvatarUrl *string     `json:"avatar_url"` // Chosen avatar image.
-	Person    *UserPerson `json:"person"`     // User objects that represent people have the type property set to "person". These objects also have the following properties:
-	Bot       *UserBot    `json:"bot"`        // A user object's type property is"bot" when the user object represents a bot. A bot user object has the following properties:
+	Object    alwaysUser  `json:"object"`               // Always "user"
+	Id        uuid.UUID   `json:"id"`                   // Unique identifier for this user.
+	Name      string      `json:"name"`                 // User's name, as displayed in Notion.
+	AvatarUrl *string     `json:"avatar_url"`           // Chosen avatar image.
+	Person    *UserPerson `json:"person"`               // User objects that represent people have the type property set to "person". These objects also have the following properties:
+	Bot       *UserBot    `json:"bot"`                  // A user object's type property is"bot" when the user object represents a bot. A bot user object has the following properties:
+	RequestId string      `json:"request_id,omitempty"` // UNDOCUMENTED
 }
 
 func (o User) MarshalJSON() ([]byte, error) {
@@ -0,0 +1,109 @@
+package notion
+
+import (
+	"context"
+	"iter"
+	"maps"
+	"slices"
+
+	"github.com/google/uuid"
+)
+
+// paginate は、fetch をカーソルを進めながら繰り返し呼び出し、全ての結果を列挙するイテレータを返します
+// ページの取得前にctxのキャンセルを確認し、エラーが発生した場合はそれを最後の要素として列挙を終了します
+func paginate[T any](ctx context.Context, fetch func(startCursor string) (*Pagination[T], error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		startCursor := ""
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(zero, err)
+				return
+			}
+
+			pagi, err := fetch(startCursor)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+
+			for _, result := range pagi.Results {
+				if !yield(result, nil) {
+					return
+				}
+			}
+
+			if !pagi.HasMore || pagi.NextCursor == nil {
+				return
+			}
+			startCursor = *pagi.NextCursor
+		}
+	}
+}
+
+// withStartCursor は start_cursor を設定したparamsのコピーを返します（呼び出し元のparamsは変更しません）
+func withStartCursor[P ~map[string]any](params P, startCursor string) P {
+	params = maps.Clone(params)
+	if params == nil {
+		params = P{}
+	}
+	if startCursor != "" {
+		params["start_cursor"] = startCursor
+	}
+	return params
+}
+
+// QueryDatabaseAll は QueryDatabase を繰り返し呼び出し、全てのページを列挙します
+func (c *Client) QueryDatabaseAll(ctx context.Context, database_id uuid.UUID, params QueryDatabaseParams, options ...CallOption) iter.Seq2[Page, error] {
+	return paginate(ctx, func(startCursor string) (*Pagination[Page], error) {
+		return c.QueryDatabase(ctx, database_id, withStartCursor(params, startCursor), options...)
+	})
+}
+
+// SearchByTitleAll は SearchByTitle を繰り返し呼び出し、全てのページとデータベースを列挙します
+func (c *Client) SearchByTitleAll(ctx context.Context, params SearchByTitleParams, options ...CallOption) iter.Seq2[PageOrDatabase, error] {
+	return paginate(ctx, func(startCursor string) (*Pagination[PageOrDatabase], error) {
+		return c.SearchByTitle(ctx, withStartCursor(params, startCursor), options...)
+	})
+}
+
+// RetrieveBlockChildrenAll は RetrieveBlockChildren を繰り返し呼び出し、全ての子ブロックを列挙します
+func (c *Client) RetrieveBlockChildrenAll(ctx context.Context, block_id uuid.UUID, params RetrieveBlockChildrenParams, options ...CallOption) iter.Seq2[Block, error] {
+	return paginate(ctx, func(startCursor string) (*Pagination[Block], error) {
+		return c.RetrieveBlockChildren(ctx, block_id, withStartCursor(params, startCursor), options...)
+	})
+}
+
+// ListUsersAll は ListUsers を繰り返し呼び出し、全てのユーザーを列挙します
+func (c *Client) ListUsersAll(ctx context.Context, params ListUsersParams, options ...CallOption) iter.Seq2[User, error] {
+	return paginate(ctx, func(startCursor string) (*Pagination[User], error) {
+		return c.ListUsers(ctx, withStartCursor(params, startCursor), options...)
+	})
+}
+
+// ListCommentsAll は ListComments を繰り返し呼び出し、全てのコメントを列挙します
+func (c *Client) ListCommentsAll(ctx context.Context, params ListCommentsParams, options ...CallOption) iter.Seq2[Comment, error] {
+	return paginate(ctx, func(startCursor string) (*Pagination[Comment], error) {
+		return c.ListComments(ctx, withStartCursor(params, startCursor), options...)
+	})
+}
+
+// RetrievePagePropertyItemAll は RetrievePagePropertyItem を繰り返し呼び出し、全てのプロパティアイテムを列挙します
+// ページネーションされないプロパティの場合は、単一のプロパティアイテムを列挙します
+func (c *Client) RetrievePagePropertyItemAll(ctx context.Context, page_id uuid.UUID, property_id string, params RetrievePagePropertyItemParams, options ...CallOption) iter.Seq2[PropertyItem, error] {
+	return paginate(ctx, func(startCursor string) (*Pagination[PropertyItem], error) {
+		options := append(slices.Clone(options), WithQuery(withStartCursor(params, startCursor)))
+		result, err := c.RetrievePagePropertyItem(ctx, page_id, property_id, options...)
+		if err != nil {
+			return nil, err
+		}
+		switch result := result.(type) {
+		case *Pagination[PropertyItem]:
+			return result, nil
+		case *PropertyItem:
+			return &Pagination[PropertyItem]{Results: []PropertyItem{*result}}, nil
+		default:
+			return &Pagination[PropertyItem]{}, nil
+		}
+	})
+}
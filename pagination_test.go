@@ -0,0 +1,73 @@
+package notion
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// stubPages は、start_cursor に応じて3ページに分かれたユーザー一覧を返す CallOption を返します
+func stubPages(cursors *[]string) CallOption {
+	pages := map[string]string{
+		"":   `{"object":"list","type":"user","user":{},"has_more":true,"next_cursor":"c1","results":[{"object":"user","id":"00000000-0000-0000-0000-000000000001"}]}`,
+		"c1": `{"object":"list","type":"user","user":{},"has_more":true,"next_cursor":"c2","results":[{"object":"user","id":"00000000-0000-0000-0000-000000000002"}]}`,
+		"c2": `{"object":"list","type":"user","user":{},"has_more":false,"next_cursor":null,"results":[{"object":"user","id":"00000000-0000-0000-0000-000000000003"}]}`,
+	}
+	return WithRoundTripper(roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		cursor := req.URL.Query().Get("start_cursor")
+		*cursors = append(*cursors, cursor)
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{},
+			Body:       io.NopCloser(strings.NewReader(pages[cursor])),
+		}, nil
+	}))
+}
+
+func TestListUsersAll(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("all", func(t *testing.T) {
+		cursors := []string{}
+		params := ListUsersParams{}
+		ids := []uuid.UUID{}
+		for user, err := range (&Client{}).ListUsersAll(ctx, params, stubPages(&cursors)) {
+			if err != nil {
+				t.Fatal(err)
+			}
+			ids = append(ids, user.Id)
+		}
+		assert.Equal(t, []string{"", "c1", "c2"}, cursors)
+		assert.Len(t, ids, 3)
+		assert.Empty(t, params) // 呼び出し元のparamsは変更されない
+	})
+
+	t.Run("break", func(t *testing.T) {
+		cursors := []string{}
+		for range (&Client{}).ListUsersAll(ctx, ListUsersParams{}, stubPages(&cursors)) {
+			break
+		}
+		assert.Equal(t, []string{""}, cursors)
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		cursors := []string{}
+		errs := []error{}
+		for _, err := range (&Client{}).ListUsersAll(ctx, ListUsersParams{}, stubPages(&cursors)) {
+			if err != nil {
+				errs = append(errs, err)
+			}
+			cancel()
+		}
+		assert.Equal(t, []string{""}, cursors)
+		assert.Equal(t, []error{context.Canceled}, errs, fmt.Sprint(errs))
+	})
+}
@@ -0,0 +1,85 @@
+package notion
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimiter はトークンバケット方式でリクエストの送信ペースを制限します
+//
+// Notion APIの制限は1インテグレーションあたり平均3リクエスト/秒なので、
+// 通常は NewRateLimiter(3, 3) のように使用します。
+// 1つの RateLimiter は複数のゴルーチンや Client から同時に使用できます。
+type RateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration // トークン1つが補充される間隔
+	burst    int           // バケットの容量
+	tokens   float64       // 現在のトークン数（予約により負になることがあります）
+	last     time.Time     // tokens を最後に更新した時刻
+	now      func() time.Time
+}
+
+// NewRateLimiter は、毎秒 rate 個のトークンを補充し、最大 burst 個まで蓄えられる RateLimiter を返します
+func NewRateLimiter(rate float64, burst int) *RateLimiter {
+	if rate <= 0 {
+		panic("notion: rate must be positive")
+	}
+	burst = max(burst, 1)
+	return &RateLimiter{
+		interval: time.Duration(float64(time.Second) / rate),
+		burst:    burst,
+		tokens:   float64(burst),
+		now:      time.Now,
+	}
+}
+
+// Wait はトークンを1つ取得できるまで待ちます
+// 待っている間に ctx がキャンセルされた場合は、予約したトークンを返却して ctx.Err() を返します
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	delay := l.reserve()
+	if delay <= 0 {
+		return nil
+	}
+
+	if err := sleep(ctx, delay); err != nil {
+		l.cancel()
+		return err
+	}
+	return nil
+}
+
+// reserve はトークンを1つ予約し、それが使用可能になるまでの待ち時間を返します
+func (l *RateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.refill()
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens * float64(l.interval))
+}
+
+// cancel は予約したトークンを返却します
+func (l *RateLimiter) cancel() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.refill()
+	l.tokens = min(l.tokens+1, float64(l.burst))
+}
+
+func (l *RateLimiter) refill() {
+	now := l.now()
+	if !l.last.IsZero() {
+		l.tokens += float64(now.Sub(l.last)) / float64(l.interval)
+		l.tokens = min(l.tokens, float64(l.burst))
+	}
+	l.last = now
+}
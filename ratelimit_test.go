@@ -0,0 +1,65 @@
+package notion
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimiter(t *testing.T) {
+	ctx := context.Background()
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	limiter := NewRateLimiter(10, 2)
+	limiter.now = func() time.Time { return now }
+
+	delays := recordSleep(t)
+	for range 4 {
+		assert.NoError(t, limiter.Wait(ctx))
+	}
+	assert.Equal(t, []time.Duration{100 * time.Millisecond, 200 * time.Millisecond}, *delays)
+
+	// 時間が経過するとトークンが補充されます（上限は burst まで）
+	now = now.Add(time.Second)
+	*delays = nil
+	for range 3 {
+		assert.NoError(t, limiter.Wait(ctx))
+	}
+	assert.Equal(t, []time.Duration{100 * time.Millisecond}, *delays)
+
+	// 待機中にキャンセルされた場合は予約が取り消されます
+	ctx, cancel := context.WithCancel(ctx)
+	sleep = func(ctx context.Context, d time.Duration) error {
+		*delays = append(*delays, d)
+		cancel()
+		return ctx.Err()
+	}
+	*delays = nil
+	tokens := limiter.tokens
+	assert.ErrorIs(t, limiter.Wait(ctx), context.Canceled)
+	assert.Equal(t, []time.Duration{200 * time.Millisecond}, *delays)
+	assert.Equal(t, tokens, limiter.tokens)
+}
+
+func TestClientRateLimiter(t *testing.T) {
+	ctx := context.Background()
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	limiter := NewRateLimiter(3, 1)
+	limiter.now = func() time.Time { return now }
+
+	delays := recordSleep(t)
+	client := NewClient("token", WithRateLimiter(limiter))
+	requests := []*http.Request{}
+	for range 3 {
+		stub := stubResponse(&requests, http.StatusOK, `{"object":"user","id":"00000000-0000-0000-0000-000000000001"}`)
+		if _, err := client.RetrieveBotUser(ctx, stub); err != nil {
+			t.Fatal(err)
+		}
+	}
+	assert.Len(t, requests, 3)
+	assert.Len(t, *delays, 2)
+}
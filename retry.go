@@ -0,0 +1,127 @@
+package notion
+
+import (
+	"context"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy は、失敗したリクエストを再試行する方法を表します
+//
+// 再試行の待ち時間は BaseDelay から再試行ごとに倍になり（上限 MaxDelay）、ジッターが加えられます。
+// レスポンスに Retry-After ヘッダーがある場合は、その指示に従います。
+type RetryPolicy struct {
+	MaxRetries int           // 再試行の最大回数
+	BaseDelay  time.Duration // 最初の再試行までの待ち時間の基準値
+	MaxDelay   time.Duration // 待ち時間の上限（Retry-After による指示には適用されません）
+}
+
+// DefaultRetryPolicy は WithRetry に渡すことができる標準的な再試行ポリシーです
+var DefaultRetryPolicy = RetryPolicy{
+	MaxRetries: 3,
+	BaseDelay:  500 * time.Millisecond,
+	MaxDelay:   30 * time.Second,
+}
+
+// WithRetry は、429 および 5xx、conflict_error のレスポンスと、一時的な TransportError を policy に従って再試行します
+//
+// 429 (rate_limited) はリクエストが処理されなかったことを意味するため、常に再試行されます。
+// それ以外のステータスと TransportError は、GET・DELETE のような冪等なリクエストか、
+// WithRetrySafe で安全であると明示されたリクエストに限り再試行されます。
+func WithRetry(policy RetryPolicy) CallOption {
+	return func(co *callOptions) {
+		co.retryPolicy = &policy
+	}
+}
+
+// WithRetrySafe は、POST や PATCH のリクエストであっても再試行して安全であることを表明します
+// QueryDatabase や SearchByTitle のような読み取り専用のエンドポイントで使用します
+func WithRetrySafe() CallOption {
+	return func(co *callOptions) {
+		co.retrySafe = true
+	}
+}
+
+// sleep は ctx がキャンセルされるまで d だけ待ちます（テストで差し替えられます）
+var sleep = func(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// retryDelay は、retries 回目の再試行を行うべきかと、それまでの待ち時間を返します
+// レスポンスを受け取れなかった場合、res は nil で transportErr にそのエラーが渡されます
+func (co *callOptions) retryDelay(method string, res *http.Response, transportErr *TransportError, retries int) (time.Duration, bool) {
+	policy := co.retryPolicy
+	if policy == nil || retries >= policy.MaxRetries {
+		return 0, false
+	}
+
+	if transportErr != nil {
+		if !transportErr.Temporary() || (!co.retrySafe && !isIdempotent(method)) {
+			return 0, false
+		}
+		return policy.backoff(retries), true
+	}
+
+	switch res.StatusCode {
+	case http.StatusTooManyRequests:
+	case http.StatusConflict, http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		if !co.retrySafe && !isIdempotent(method) {
+			return 0, false
+		}
+	default:
+		return 0, false
+	}
+
+	if delay, ok := parseRetryAfter(res.Header.Get("Retry-After")); ok {
+		return delay, true
+	}
+	return policy.backoff(retries), true
+}
+
+// backoff は retries 回目の再試行までの待ち時間を、ジッターを加えて返します
+func (policy *RetryPolicy) backoff(retries int) time.Duration {
+	backoff := policy.BaseDelay << retries
+	if backoff <= 0 || (policy.MaxDelay > 0 && backoff > policy.MaxDelay) {
+		backoff = policy.MaxDelay
+	}
+	if backoff <= 0 {
+		return 0
+	}
+	// 待ち時間の半分を固定し、残りの半分をランダムにします
+	return backoff/2 + rand.N(backoff/2+1)
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// parseRetryAfter は Retry-After ヘッダーの値（秒数またはHTTP日付）を解釈します
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+	return 0, false
+}
@@ -0,0 +1,121 @@
+package notion
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// toServer は、リクエストを server に転送する CallOption を返します
+func toServer(server *httptest.Server) CallOption {
+	serverURL, _ := url.Parse(server.URL)
+	return WithRoundTripper(roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		req.URL.Scheme = serverURL.Scheme
+		req.URL.Host = serverURL.Host
+		return server.Client().Transport.RoundTrip(req)
+	}))
+}
+
+// recordSleep は sleep を差し替え、待ち時間を記録します
+func recordSleep(t *testing.T) *[]time.Duration {
+	delays := []time.Duration{}
+	original := sleep
+	sleep = func(ctx context.Context, d time.Duration) error {
+		delays = append(delays, d)
+		return ctx.Err()
+	}
+	t.Cleanup(func() { sleep = original })
+	return &delays
+}
+
+// flakyServer は、最初の failures 回は status を返し、その後は成功するサーバーを返します
+func flakyServer(t *testing.T, failures int, status int, header http.Header) (*httptest.Server, *[]string) {
+	bodies := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if len(bodies) <= failures {
+			for k, v := range header {
+				w.Header()[k] = v
+			}
+			w.WriteHeader(status)
+			w.Write([]byte(`{"object":"error","status":429,"code":"rate_limited","message":"slow down"}`))
+			return
+		}
+		w.Write([]byte(`{"object":"list","type":"page_or_database","page_or_database":{},"results":[],"has_more":false,"next_cursor":null}`))
+	}))
+	t.Cleanup(server.Close)
+	return server, &bodies
+}
+
+func TestRetry(t *testing.T) {
+	ctx := context.Background()
+	databaseId := uuid.MustParse("c02fc1d3-db8b-45c5-a222-27595b15aea7")
+	policy := RetryPolicy{MaxRetries: 3, BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+
+	t.Run("Retry-After", func(t *testing.T) {
+		delays := recordSleep(t)
+		server, bodies := flakyServer(t, 2, http.StatusTooManyRequests, http.Header{"Retry-After": {"3"}})
+
+		params := QueryDatabaseParams{}
+		params.PageSize(1)
+		_, err := (&Client{}).QueryDatabase(ctx, databaseId, params, toServer(server), WithRetry(policy))
+		if assert.NoError(t, err) {
+			assert.Equal(t, []time.Duration{3 * time.Second, 3 * time.Second}, *delays)
+			assert.Equal(t, []string{`{"page_size":1}`, `{"page_size":1}`, `{"page_size":1}`}, *bodies)
+		}
+	})
+
+	t.Run("backoff", func(t *testing.T) {
+		delays := recordSleep(t)
+		server, _ := flakyServer(t, 3, http.StatusServiceUnavailable, nil)
+
+		_, err := (&Client{}).QueryDatabase(ctx, databaseId, QueryDatabaseParams{}, toServer(server), WithRetry(policy), WithRetrySafe())
+		if assert.NoError(t, err) && assert.Len(t, *delays, 3) {
+			for i, max := range []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond} {
+				assert.GreaterOrEqual(t, (*delays)[i], max/2)
+				assert.LessOrEqual(t, (*delays)[i], max)
+			}
+		}
+	})
+
+	t.Run("unsafe", func(t *testing.T) {
+		delays := recordSleep(t)
+		server, bodies := flakyServer(t, 1, http.StatusServiceUnavailable, nil)
+
+		_, err := (&Client{}).QueryDatabase(ctx, databaseId, QueryDatabaseParams{}, toServer(server), WithRetry(policy))
+		assert.Error(t, err)
+		assert.Empty(t, *delays)
+		assert.Len(t, *bodies, 1)
+	})
+
+	t.Run("transport", func(t *testing.T) {
+		delays := recordSleep(t)
+		attempts := 0
+		flaky := WithRoundTripper(roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			attempts++
+			return nil, io.ErrUnexpectedEOF
+		}))
+
+		_, err := (&Client{}).RetrieveBotUser(ctx, flaky, WithRetry(policy))
+		assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+		assert.Equal(t, 4, attempts)
+		assert.Len(t, *delays, 3)
+	})
+
+	t.Run("MaxRetries", func(t *testing.T) {
+		recordSleep(t)
+		server, bodies := flakyServer(t, 10, http.StatusTooManyRequests, nil)
+
+		_, err := (&Client{}).QueryDatabase(ctx, databaseId, QueryDatabaseParams{}, toServer(server), WithRetry(policy))
+		assert.ErrorIs(t, err, ErrRateLimited)
+		assert.Len(t, *bodies, 4)
+	})
+}
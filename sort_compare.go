@@ -0,0 +1,246 @@
+package notion
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Compare は s に従って a と b を比較し、a が先なら負、b が先なら正、順序が決まらなければ0を返します
+// 値が空のページは、並べ替えの方向に関わらず後ろに配置されます
+// select と status はスキーマにおける選択肢の順に、multi_select は最初の選択肢の順に並べられます
+func (s Sort) Compare(a, b *Page, schema map[string]Property) (int, error) {
+	var descending bool
+	switch s.Direction {
+	case "ascending":
+	case "descending":
+		descending = true
+	default:
+		return 0, fmt.Errorf("unknown sort direction: %q", s.Direction)
+	}
+
+	ka, err := s.key(a, schema)
+	if err != nil {
+		return 0, err
+	}
+	kb, err := s.key(b, schema)
+	if err != nil {
+		return 0, err
+	}
+
+	switch {
+	case ka.empty && kb.empty:
+		return 0, nil
+	case ka.empty:
+		return 1, nil
+	case kb.empty:
+		return -1, nil
+	case descending:
+		return kb.compare(ka), nil
+	default:
+		return ka.compare(kb), nil
+	}
+}
+
+// SortPages は sorts に従って pages を安定ソートします
+// 先頭の Sort で順序が決まらない場合は、次の Sort で比較します
+func SortPages(pages []Page, sorts []Sort, schema map[string]Property) error {
+	var sortErr error
+	slices.SortStableFunc(pages, func(a, b Page) int {
+		for _, s := range sorts {
+			c, err := s.Compare(&a, &b, schema)
+			if err != nil {
+				sortErr = err
+				return 0
+			}
+			if c != 0 {
+				return c
+			}
+		}
+		return 0
+	})
+	return sortErr
+}
+
+// sortKey は並べ替えに使用するページの値です
+type sortKey struct {
+	empty  bool
+	number float64
+	text   string
+}
+
+func (k sortKey) compare(o sortKey) int {
+	if c := cmp.Compare(k.number, o.number); c != 0 {
+		return c
+	}
+	return strings.Compare(k.text, o.text)
+}
+
+// key は page の並べ替えに使用する値を返します
+func (s Sort) key(page *Page, schema map[string]Property) (sortKey, error) {
+	switch {
+	case s.Timestamp == "created_time":
+		return dateKey(page.CreatedTime, nil)
+	case s.Timestamp == "last_edited_time":
+		return dateKey(page.LastEditedTime, nil)
+	case s.Timestamp != "":
+		return sortKey{}, fmt.Errorf("unknown timestamp: %q", s.Timestamp)
+	case s.Property == "":
+		return sortKey{}, fmt.Errorf("sort has neither property nor timestamp")
+	}
+
+	prop, ok := findSchemaProperty(schema, s.Property)
+	if !ok {
+		return sortKey{}, fmt.Errorf("property %q does not exist in the schema", s.Property)
+	}
+	value := page.Properties.Get(prop.Id)
+	if value == nil {
+		v := page.Properties[prop.Name]
+		value = &v
+	}
+	return valueKey(prop, value)
+}
+
+// valueKey は種類が prop.Type のプロパティ値 value の並べ替えに使用する値を返します
+func valueKey(prop Property, value *PropertyValue) (sortKey, error) {
+	switch prop.Type {
+	case "title":
+		return textKey(value.Title.String()), nil
+	case "rich_text":
+		return textKey(value.RichText.String()), nil
+	case "url":
+		return textKey(deref(value.Url)), nil
+	case "email":
+		return textKey(deref(value.Email)), nil
+	case "phone_number":
+		return textKey(deref(value.PhoneNumber)), nil
+	case "number":
+		return numberKey(value.Number), nil
+	case "checkbox":
+		if value.Checkbox {
+			return sortKey{number: 1}, nil
+		}
+		return sortKey{}, nil
+	case "select":
+		return optionKey(prop.Select, value.Select), nil
+	case "status":
+		if prop.Status == nil {
+			return optionKey(nil, value.Status), nil
+		}
+		return optionKey(&PropertySelect{Options: prop.Status.Options}, value.Status), nil
+	case "multi_select":
+		if len(value.MultiSelect) == 0 {
+			return sortKey{empty: true}, nil
+		}
+		if prop.MultiSelect == nil {
+			return optionKey(nil, &value.MultiSelect[0]), nil
+		}
+		return optionKey(&PropertySelect{Options: prop.MultiSelect.Options}, &value.MultiSelect[0]), nil
+	case "date":
+		if value.Date == nil {
+			return sortKey{empty: true}, nil
+		}
+		return dateKey(value.Date.Start, value.Date.TimeZone)
+	case "created_time":
+		return dateKey(value.CreatedTime, nil)
+	case "last_edited_time":
+		return dateKey(value.LastEditedTime, nil)
+	case "people":
+		if len(value.People) == 0 {
+			return sortKey{empty: true}, nil
+		}
+		return textKey(value.People[0].Name), nil
+	case "created_by":
+		return textKey(value.CreatedBy.Name), nil
+	case "last_edited_by":
+		return textKey(value.LastEditedBy.Name), nil
+	case "relation":
+		if len(value.Relation) == 0 {
+			return sortKey{empty: true}, nil
+		}
+		return sortKey{number: float64(len(value.Relation))}, nil
+	case "files":
+		if len(value.Files) == 0 {
+			return sortKey{empty: true}, nil
+		}
+		return textKey(value.Files[0].Name), nil
+	case "unique_id":
+		if value.UniqueId == nil {
+			return sortKey{empty: true}, nil
+		}
+		return sortKey{number: float64(value.UniqueId.Number)}, nil
+	case "formula":
+		if value.Formula == nil {
+			return sortKey{empty: true}, nil
+		}
+		switch value.Formula.Type {
+		case "string":
+			return textKey(deref(value.Formula.String)), nil
+		case "number":
+			return sortKey{number: value.Formula.Number}, nil
+		case "boolean":
+			if value.Formula.Boolean {
+				return sortKey{number: 1}, nil
+			}
+			return sortKey{}, nil
+		case "date":
+			return dateKey(value.Formula.Date.Start, value.Formula.Date.TimeZone)
+		}
+		return sortKey{empty: true}, nil
+	case "rollup":
+		if value.Rollup == nil {
+			return sortKey{empty: true}, nil
+		}
+		switch value.Rollup.Type {
+		case "number":
+			return numberKey(value.Rollup.Number), nil
+		case "date":
+			if value.Rollup.Date == nil {
+				return sortKey{empty: true}, nil
+			}
+			return dateKey(value.Rollup.Date.Start, value.Rollup.Date.TimeZone)
+		case "array":
+			return sortKey{number: float64(len(value.Rollup.Array))}, nil
+		}
+		return sortKey{empty: true}, nil
+	default:
+		return sortKey{}, fmt.Errorf("cannot sort by %s property %q", prop.Type, prop.Name)
+	}
+}
+
+func textKey(s string) sortKey {
+	return sortKey{empty: s == "", text: s}
+}
+
+func numberKey(n *float64) sortKey {
+	if n == nil {
+		return sortKey{empty: true}
+	}
+	return sortKey{number: *n}
+}
+
+// optionKey は、スキーマにおける選択肢の位置を並べ替えの値とします
+func optionKey(schema *PropertySelect, option *Option) sortKey {
+	if option == nil || option.Name == "" {
+		return sortKey{empty: true}
+	}
+	if schema != nil {
+		if i := slices.IndexFunc(schema.Options, func(o OptionDescription) bool { return o.Name == option.Name }); i != -1 {
+			return sortKey{number: float64(i)}
+		}
+		return sortKey{number: float64(len(schema.Options)), text: option.Name}
+	}
+	return sortKey{text: option.Name}
+}
+
+func dateKey(value string, timeZone *string) (sortKey, error) {
+	if value == "" {
+		return sortKey{empty: true}, nil
+	}
+	t, _, err := parseDate(value, timeZone)
+	if err != nil {
+		return sortKey{}, err
+	}
+	return sortKey{number: float64(t.UnixMilli())}, nil
+}
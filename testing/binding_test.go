@@ -50,6 +50,7 @@ type TheDatabase struct {
 }
 
 func TestBinding(t *testing.T) {
+
 	ctx := context.Background()
 
 	t.Run("ToTaggedStruct", func(t *testing.T) {
@@ -69,8 +70,30 @@ func TestBinding(t *testing.T) {
 			return record
 		})
 
-		actualRecords := string(lo.Must(json.MarshalIndent(records, "", "  ")))
-		assert.JSONEq(t, expectedRecords, actualRecords)
+		if live {
+			actualRecords := string(lo.Must(json.MarshalIndent(records, "", "  ")))
+			assert.JSONEq(t, expectedRecords, actualRecords)
+			return
+		}
+
+		// notiontest では seedFixtures で作成したページの値を確認します
+		if !assert.Len(t, records, 3) {
+			return
+		}
+		read1, read2, write := records[0], records[1], records[2]
+		assert.Equal(t, "ABCDEFG", notion.RichTextArray(read1.Title).String())
+		assert.Equal(t, lo.ToPtr(3.1415926535898), read1.Number)
+		assert.Equal(t, notion.Option{Id: "26050216-9669-4b07-9437-951b6ffabfc1", Name: "Y", Color: "yellow"}, *read1.Select)
+		assert.Equal(t, []string{"R", "G", "B"}, lo.Map(read1.MultiSelect, func(o notion.Option, _ int) string { return o.Name }))
+		assert.Equal(t, []notion.PageReference{{Id: DATABASE_PAGE_FOR_READ1}, {Id: DATABASE_PAGE_FOR_WRITE}}, read1.DualRelation)
+		assert.Equal(t, "2023-04-01", read1.Date.Start)
+		assert.Equal(t, lo.ToPtr("http://example.com"), read1.URL)
+		assert.Equal(t, "V", read2.Select.Name)
+		assert.Nil(t, read2.Number)
+		assert.Empty(t, read2.Title)
+		assert.True(t, write.Checkbox)
+		assert.Equal(t, "Not started", write.Status.Name)
+		assert.Equal(t, []notion.PageReference{{Id: DATABASE_PAGE_FOR_READ1}}, write.SingleRelation)
 	})
 
 	t.Run("GetUpdatePageParams", func(t *testing.T) {
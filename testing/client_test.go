@@ -13,6 +13,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/joho/godotenv"
 	. "github.com/psyark/notion"
+	"github.com/psyark/notion/notiontest"
 	"github.com/samber/lo"
 )
 
@@ -20,8 +21,12 @@ import (
 
 var client *Client
 
+// live は実際のワークスペースに対してテストするかどうかです
+var live = os.Getenv("NOTION_LIVE_TEST") != ""
+
 var (
 	// TODO 環境変数に移動
+	// 以下は実際のワークスペースのIDです。notiontest に対してテストする場合は seedFixtures で置き換えられます
 	ROOT                    = uuid.MustParse("9c20de5e26af4959a26e390b537af4c8") // https://www.notion.so/Root-9c20de5e26af4959a26e390b537af4c8
 	STANDALONE_PAGE         = uuid.MustParse("b05213d5c3af4de6924cc9b106ae93ec") // https://www.notion.so/Page-b05213d5c3af4de6924cc9b106ae93ec
 	DATABASE                = uuid.MustParse("edd0404128004a83bd29deb729221ec7") // https://www.notion.so/edd0404128004a83bd29deb729221ec7
@@ -30,7 +35,27 @@ var (
 	DATABASE_PAGE_FOR_WRITE = uuid.MustParse("b8ff7c186ef2416cb9654daf0d7aa961") // https://www.notion.so/PageToUpdate-b8ff7c186ef2416cb9654daf0d7aa961
 )
 
+/*
+TestMain はテストの接続先を準備します
+
+通常は notiontest のサーバーに対してテストします。
+環境変数 NOTION_LIVE_TEST が設定されている場合は、env.local の API_ACCESS_TOKEN を使用して実際のワークスペースに対してテストします。
+この場合、ROOT の下にある前回のテストで生成されたページはゴミ箱に移動されます。
+*/
 func TestMain(m *testing.M) {
+	lo.Must0(os.MkdirAll("testdata/pass", 0777))
+	lo.Must0(os.MkdirAll("testdata/fail", 0777))
+
+	if !live {
+		server := notiontest.NewServer()
+		client = server.NewClient()
+		seedFixtures(server)
+
+		code := m.Run()
+		server.Close()
+		os.Exit(code)
+	}
+
 	ctx := context.Background()
 
 	lo.Must0(godotenv.Load("env.local"))
@@ -155,14 +180,14 @@ func TestClient(t *testing.T) {
 			{Heading1: &BlockHeading{RichText: NewRichTextArray("Heading 1")}},
 			{Heading2: &BlockHeading{RichText: NewRichTextArray("Heading 2")}},
 			{Heading3: &BlockHeading{RichText: NewRichTextArray("Heading 3")}},
-			{Heading1: &BlockHeading{RichText: NewRichTextArray("Heading 1"), IsToggleable: true, Children: []Block{
+			{Heading1: &BlockHeading{RichText: NewRichTextArray("Heading 1"), IsToggleable: lo.ToPtr(true), Children: []Block{
 				{Callout: &BlockCallout{
 					RichText: NewRichTextArray("コールアウト"),
 					Icon:     &Emoji{Emoji: "🍣"},
 				}},
 			}}},
-			{Heading2: &BlockHeading{RichText: NewRichTextArray("Heading 2"), IsToggleable: true}},
-			{Heading3: &BlockHeading{RichText: NewRichTextArray("Heading 3"), IsToggleable: true}},
+			{Heading2: &BlockHeading{RichText: NewRichTextArray("Heading 2"), IsToggleable: lo.ToPtr(true)}},
+			{Heading3: &BlockHeading{RichText: NewRichTextArray("Heading 3"), IsToggleable: lo.ToPtr(true)}},
 			{ToDo: &BlockToDo{RichText: NewRichTextArray("To Do")}},
 			{SyncedBlock: &BlockSyncedBlock{
 				Children: []Block{
@@ -175,6 +200,7 @@ func TestClient(t *testing.T) {
 }
 
 func TestRetrievePagePropertyItem(t *testing.T) {
+
 	ctx := context.Background()
 	if db, err := client.RetrieveDatabase(ctx, DATABASE, useCache(t), compareJSON(t)); err != nil {
 		t.Fatal(err)
@@ -194,6 +220,7 @@ func TestRetrievePagePropertyItem(t *testing.T) {
 }
 
 func TestUpdatePage(t *testing.T) {
+
 	ctx := context.Background()
 
 	configs := []func(p UpdatePagePropertiesParams){
@@ -230,6 +257,7 @@ func TestUpdatePage(t *testing.T) {
 }
 
 func TestQueryDatabase(t *testing.T) {
+
 	filters := []Filter{
 		{
 			Property: "URL",
@@ -252,13 +280,93 @@ func TestQueryDatabase(t *testing.T) {
 	}
 }
 
+func TestUpdateBlock(t *testing.T) {
+	ctx := context.Background()
+
+	params := AppendBlockChildrenParams{}
+	params.Children([]Block{{ToDo: &BlockToDo{RichText: NewRichTextArray("To Do")}}})
+	pagi := lo.Must(client.AppendBlockChildren(ctx, STANDALONE_PAGE, params))
+	blockId := pagi.Results[0].Id
+
+	t.Run("RetrieveBlock", func(t *testing.T) {
+		lo.Must(client.RetrieveBlock(ctx, blockId, compareJSON(t)))
+	})
+
+	t.Run("UpdateBlock", func(t *testing.T) {
+		params := UpdateBlockParams{}
+		params.ToDo(BlockToDo{Checked: lo.ToPtr(true)})
+		block := lo.Must(client.UpdateBlock(ctx, blockId, params, compareJSON(t)))
+		if block.ToDo.RichText.String() != "To Do" || !*block.ToDo.Checked {
+			t.Fatal(block.ToDo)
+		}
+	})
+
+	t.Run("RestoreBlock", func(t *testing.T) {
+		lo.Must(client.DeleteBlock(ctx, blockId))
+
+		params := UpdateBlockParams{}
+		params.InTrash(false)
+		block := lo.Must(client.UpdateBlock(ctx, blockId, params, compareJSON(t)))
+		if block.InTrash {
+			t.Fatal(block)
+		}
+	})
+}
+
 func TestRetrieveBlockChildren(t *testing.T) {
 	ctx := context.Background()
 
-	pagi := lo.Must(client.RetrieveBlockChildren(ctx, STANDALONE_PAGE, compareJSON(t)))
+	params := RetrieveBlockChildrenParams{}
+	params.PageSize(100)
+	pagi := lo.Must(client.RetrieveBlockChildren(ctx, STANDALONE_PAGE, params, compareJSON(t)))
 	for _, block := range pagi.Results {
 		t.Run(block.Id.String(), func(t *testing.T) {
 			lo.Must(client.DeleteBlock(ctx, block.Id, compareJSON(t)))
 		})
 	}
 }
+
+func TestUsers(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("RetrieveBotUser", func(t *testing.T) {
+		lo.Must(client.RetrieveBotUser(ctx, useCache(t), compareJSON(t)))
+	})
+
+	t.Run("ListUsers", func(t *testing.T) {
+		params := ListUsersParams{}
+		params.PageSize(10)
+		pagi := lo.Must(client.ListUsers(ctx, params, useCache(t), compareJSON(t)))
+		for _, user := range pagi.Results {
+			t.Run(user.Id.String(), func(t *testing.T) {
+				lo.Must(client.RetrieveUser(ctx, user.Id, useCache(t), compareJSON(t)))
+			})
+		}
+	})
+}
+
+func TestComments(t *testing.T) {
+	ctx := context.Background()
+
+	var comment *Comment
+
+	t.Run("CreateComment", func(t *testing.T) {
+		params := CreateCommentParams{}
+		params.Parent(Parent{PageId: STANDALONE_PAGE})
+		params.RichText(NewRichTextArray("コメント"))
+		comment = lo.Must(client.CreateComment(ctx, params, compareJSON(t)))
+	})
+
+	t.Run("ReplyComment", func(t *testing.T) {
+		params := CreateCommentParams{}
+		params.DiscussionId(comment.DiscussionId)
+		params.RichText(NewRichTextArray("返信"))
+		lo.Must(client.CreateComment(ctx, params, compareJSON(t)))
+	})
+
+	t.Run("ListComments", func(t *testing.T) {
+		params := ListCommentsParams{}
+		params.BlockId(STANDALONE_PAGE)
+		lo.Must(client.ListComments(ctx, params, compareJSON(t)))
+	})
+}
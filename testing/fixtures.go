@@ -0,0 +1,116 @@
+package testing
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	. "github.com/psyark/notion"
+	"github.com/psyark/notion/notiontest"
+	"github.com/samber/lo"
+)
+
+/*
+seedFixtures は、実際のワークスペースのテスト用データを notiontest のサーバーに再現し、ROOT などのIDを置き換えます
+
+DATABASE のスキーマは実際のワークスペースと同じプロパティの名前・ID・選択肢を持つため、
+testdata/tagged.txt などのプロパティIDに依存する期待値をそのまま使用できます。
+ページの値は実際のワークスペースのうち、テストが参照するものだけを再現します。
+*/
+func seedFixtures(server *notiontest.Server) {
+	ctx := context.Background()
+
+	ROOT = server.AddPage("Root")
+	STANDALONE_PAGE = server.AddPage("Page")
+
+	// リレーションの関連先はこのデータベース自身です
+	DATABASE = uuid.New()
+	server.AddDatabase(STANDALONE_PAGE, Database{
+		Id:    DATABASE,
+		Title: NewRichTextArray("Database"),
+		Properties: map[string]Property{
+			"Title":    {Id: "title", Title: &struct{}{}},
+			"Text":     {Id: "Vl%40o", RichText: &struct{}{}},
+			"Number":   {Id: "wSuU", Number: &PropertyNumber{Format: "number"}},
+			"Checkbox": {Id: "%3Dh%3AT", Checkbox: &struct{}{}},
+			"Date":     {Id: "gegF", Date: &struct{}{}},
+			"URL":      {Id: "nKu_", Url: &struct{}{}},
+			"Mail":     {Id: "l_GI", Email: &struct{}{}},
+			"Phone":    {Id: "%7Cb%60H", PhoneNumber: &struct{}{}},
+			"User":     {Id: "Ui%5B%3A", People: &struct{}{}},
+			"File":     {Id: "%7Dlj%7B", Files: &struct{}{}},
+			"Select": {Id: "DaP%40", Select: &PropertySelect{Options: []OptionDescription{
+				{Id: "26050216-9669-4b07-9437-951b6ffabfc1", Name: "Y", Color: "yellow"},
+				{Id: "57defd6d-39d5-4786-b343-1235a5e8abc4", Name: "V", Color: "purple"},
+				{Id: "e756421d-e0d8-401d-9e01-4202ce444b6e", Name: "P", Color: "pink"},
+			}}},
+			"MultiSelect": {Id: "qe%60%5E", MultiSelect: &PropertyMultiSelect{Options: []OptionDescription{
+				{Id: "99d5434e-1020-48ab-8986-32b30919da4d", Name: "R", Color: "red"},
+				{Id: "0e4049da-7127-498a-871c-3efee62686e7", Name: "G", Color: "green"},
+				{Id: "f4cb398a-7b27-4b35-a6c9-1acf388bd25a", Name: "B", Color: "blue"},
+			}}},
+			"Status": {Id: "~_pB", Status: &PropertyStatus{Options: []OptionDescription{
+				{Id: "e06fafe8-bf2e-4911-a125-d49ed84dfb54", Name: "Not started", Color: "default"},
+			}}},
+			"Formula": {Id: "kutj", Formula: &PropertyFormula{Expression: `prop("CreatedBy") + "/" + prop("CreatedTime")`}},
+			"DualRelation": {Id: "Dopp", Relation: &PropertyRelation{
+				DatabaseId:   DATABASE,
+				DualProperty: &PropertyRelationDualProperty{SyncedPropertyId: "vxwW", SyncedPropertyName: "DualRelation(back)"},
+			}},
+			"DualRelation(back)": {Id: "vxwW", Relation: &PropertyRelation{
+				DatabaseId:   DATABASE,
+				DualProperty: &PropertyRelationDualProperty{SyncedPropertyId: "Dopp", SyncedPropertyName: "DualRelation"},
+			}},
+			"SingleRelation": {Id: "kOoD", Relation: &PropertyRelation{DatabaseId: DATABASE, SingleProperty: &struct{}{}}},
+			"ArrayRollup":    {Id: "lxhZ", Rollup: &PropertyRollup{RelationPropertyName: "DualRelation", RollupPropertyName: "Title", Function: "show_original"}},
+			"NumberRollup":   {Id: "QdI%3C", Rollup: &PropertyRollup{RelationPropertyName: "SingleRelation", RollupPropertyName: "Title", Function: "count_values"}},
+			"CreatedTime":    {Id: "Ldgn", CreatedTime: &struct{}{}},
+			"CreatedBy":      {Id: "TB%5Dl", CreatedBy: &struct{}{}},
+			"LastEditedTime": {Id: "%7B%7Cmj", LastEditedTime: &struct{}{}},
+			"LastEditedBy":   {Id: "CA~Q", LastEditedBy: &struct{}{}},
+			"Button":         {Id: "ogOz", Button: &struct{}{}},
+			"ID":             {Id: "Rmmz", UniqueId: &PropertyUniqueId{Prefix: lo.ToPtr("ID")}},
+		},
+	})
+
+	createPage := func(properties PropertyValueMap) uuid.UUID {
+		params := CreatePageParams{}
+		params.Parent(Parent{DatabaseId: DATABASE})
+		params.Properties(properties)
+		return lo.Must(client.CreatePage(ctx, params)).Id
+	}
+
+	DATABASE_PAGE_FOR_READ1 = createPage(PropertyValueMap{
+		"Title":       {Title: NewRichTextArray("ABCDEFG")},
+		"Text":        {RichText: NewRichTextArray("ABCDEFG")},
+		"Number":      {Number: lo.ToPtr(3.1415926535898)},
+		"Select":      {Select: &Option{Name: "Y"}},
+		"MultiSelect": {MultiSelect: []Option{{Name: "R"}, {Name: "G"}, {Name: "B"}}},
+		"Status":      {Status: &Option{Name: "Not started"}},
+		"Date":        {Date: &PropertyValueDate{Start: "2023-04-01"}},
+		"URL":         {Url: lo.ToPtr("http://example.com")},
+		"Mail":        {Email: lo.ToPtr("me@example.com")},
+		"Phone":       {PhoneNumber: lo.ToPtr("0120-444-444")},
+	})
+	DATABASE_PAGE_FOR_READ2 = createPage(PropertyValueMap{
+		"Select": {Select: &Option{Name: "V"}},
+		"Status": {Status: &Option{Name: "Not started"}},
+	})
+	DATABASE_PAGE_FOR_WRITE = createPage(PropertyValueMap{
+		"Title":              {Title: NewRichTextArray("PageToUpdate")},
+		"Number":             {Number: lo.ToPtr(632.5792758957873)},
+		"Select":             {Select: &Option{Name: "P"}},
+		"Status":             {Status: &Option{Name: "Not started"}},
+		"Checkbox":           {Checkbox: true},
+		"Date":               {Date: &PropertyValueDate{Start: "2024-07-13T04:07:00.000+09:00"}},
+		"SingleRelation":     {Relation: []PageReference{{Id: DATABASE_PAGE_FOR_READ1}}},
+		"DualRelation(back)": {Relation: []PageReference{{Id: DATABASE_PAGE_FOR_READ1}}},
+	})
+
+	params := UpdatePagePropertiesParams{}
+	params.Properties(PropertyValueMap{
+		"DualRelation":       {Relation: []PageReference{{Id: DATABASE_PAGE_FOR_READ1}, {Id: DATABASE_PAGE_FOR_WRITE}}},
+		"DualRelation(back)": {Relation: []PageReference{{Id: DATABASE_PAGE_FOR_READ1}}},
+		"SingleRelation":     {Relation: []PageReference{{Id: DATABASE_PAGE_FOR_READ1}, {Id: DATABASE_PAGE_FOR_WRITE}}},
+	})
+	lo.Must(client.UpdatePageProperties(ctx, DATABASE_PAGE_FOR_READ1, params))
+}
@@ -18,7 +18,11 @@ type cache struct {
 }
 
 // TODO 引数を *testing.T にする
+// notiontest に対してテストする場合はキャッシュしません
 func useCache(t *testing.T) notion.CallOption {
+	if !live {
+		return notion.WithMiddleware()
+	}
 	fileName := lo.Must(filenamify.FilenamifyV2(t.Name()))
 	return notion.WithRoundTripper(&cache{filePath: fmt.Sprintf("testdata/cache/%s", fileName)})
 }
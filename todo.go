@@ -5,9 +5,6 @@ import (
 	"github.com/samber/lo"
 )
 
-// TODO
-type Comment struct{}
-
 // TODO 生成
 type SearchFilter struct {
 	Value    string `json:"value"`    // The value of the property to filter the results by. Possible values for object type include page or database. Limitation: Currently the only filter allowed is object which will filter by type of object (either page or database)